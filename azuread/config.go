@@ -12,87 +12,214 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform/httpclient"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/msgraph"
 	"github.com/terraform-providers/terraform-provider-azuread/version"
 )
 
 // ArmClient contains the handles to all the specific Azure ADger resource classes' respective clients.
 type ArmClient struct {
-	subscriptionID string
-	clientID       string
-	tenantID       string
-	environment    azure.Environment
+	clientID    string
+	tenantID    string
+	environment azure.Environment
+
+	// requireResourcesToBeImported causes a Create to fail when the object it would create already exists
+	requireResourcesToBeImported bool
 
 	StopContext context.Context
 
 	// azure AD clients
-	applicationsClient      graphrbac.ApplicationsClient
-	domainsClient           graphrbac.DomainsClient
-	groupsClient            graphrbac.GroupsClient
-	servicePrincipalsClient graphrbac.ServicePrincipalsClient
-	usersClient             graphrbac.UsersClient
+	appRoleAssignmentsClient graph.AppRoleAssignmentsAPI
+	applicationsClient       graph.ApplicationsAPI
+	domainsClient            graph.DomainsAPI
+	groupsClient             graph.GroupsAPI
+	oauth2Client             graph.OAuth2PermissionGrantsAPI
+	objectsClient            graph.ObjectsAPI
+	servicePrincipalsClient  graph.ServicePrincipalsAPI
+	usersClient              graph.UsersAPI
+}
+
+// ClientOptions contains the provider settings which control how requests are sent to the Graph API
+type ClientOptions struct {
+	// MaxRetries is the number of times a request which failed with a transient error is retried
+	MaxRetries int
+
+	// MaxRequestsPerSecond caps the rate at which requests are sent, zero means unlimited
+	MaxRequestsPerSecond int
+
+	// UseMicrosoftGraph selects Microsoft Graph (graph.microsoft.com) in place of Azure AD Graph (graph.windows.net)
+	UseMicrosoftGraph bool
 }
 
+const (
+	retryMinBackoff = 2 * time.Second
+	retryMaxBackoff = 60 * time.Second
+)
+
 // getArmClient is a helper method which returns a fully instantiated *ArmClient based on the auth Config's current settings.
-func getArmClient(authCfg *authentication.Config) (*ArmClient, error) {
+func getArmClient(authCfg *authentication.Config, opts ClientOptions) (*ArmClient, error) {
 	env, err := authentication.DetermineEnvironment(authCfg.Environment)
 	if err != nil {
 		return nil, err
 	}
 
-	// client declarations:
-	client := ArmClient{
-		subscriptionID: authCfg.SubscriptionID,
-		clientID:       authCfg.ClientID,
-		tenantID:       authCfg.TenantID,
-		environment:    *env,
+	// Graph Endpoints
+	graphEndpoint := env.GraphEndpoint
+	if opts.UseMicrosoftGraph {
+		if graphEndpoint, err = msgraph.EnvironmentEndpoint(*env); err != nil {
+			return nil, err
+		}
 	}
 
-	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, client.tenantID)
+	graphAuthorizer, err := getAuthorizationToken(authCfg, *env, authCfg.TenantID, graphEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
+	// the tokens for the auxiliary tenants are sent alongside the token for the primary tenant, so that a multi-tenant
+	// application can reach objects in those tenants - obtaining them here also reports a missing consent up-front
+	auxiliaryAuthorizers := make([]autorest.Authorizer, 0, len(authCfg.AuxiliaryTenantIDs))
+	for _, tenantId := range authCfg.AuxiliaryTenantIDs {
+		authorizer, err := getAuthorizationToken(authCfg, *env, tenantId, graphEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		auxiliaryAuthorizers = append(auxiliaryAuthorizers, authorizer)
+	}
+	graphAuthorizer = authentication.NewAuxiliaryTenantsAuthorizer(graphAuthorizer, auxiliaryAuthorizers)
+
+	return buildArmClient(authCfg, *env, graphEndpoint, graphAuthorizer, opts), nil
+}
+
+// getAuthorizationToken returns an authorizer for the Graph endpoint in the given tenant
+func getAuthorizationToken(authCfg *authentication.Config, env azure.Environment, tenantId, endpoint string) (autorest.Authorizer, error) {
+	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, tenantId)
+	if err != nil {
+		return nil, fmt.Errorf("Error configuring OAuth for tenant %q: %+v", tenantId, err)
+	}
+
 	// OAuthConfigForTenant returns a pointer, which can be nil.
 	if oauthConfig == nil {
-		return nil, fmt.Errorf("Unable to configure OAuthConfig for tenant %s", client.tenantID)
+		return nil, fmt.Errorf("Unable to configure OAuthConfig for tenant %s", tenantId)
 	}
 
-	// Graph Endpoints
-	graphEndpoint := env.GraphEndpoint
-	graphAuthorizer, err := authCfg.GetAuthorizationToken(oauthConfig, graphEndpoint)
+	authorizer, err := authCfg.GetAuthorizationToken(oauthConfig, endpoint)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Error obtaining an authorization token for tenant %q: %+v", tenantId, err)
 	}
 
-	client.registerGraphRBACClients(graphEndpoint, authCfg.TenantID, graphAuthorizer)
+	return authorizer, nil
+}
+
+// buildArmClient returns an *ArmClient whose clients send requests to the given Graph endpoint using the authorizer,
+// allowing the acceptance tests to point the provider at a fake Graph API.
+func buildArmClient(authCfg *authentication.Config, env azure.Environment, graphEndpoint string, graphAuthorizer autorest.Authorizer, opts ClientOptions) *ArmClient {
+	// client declarations:
+	client := ArmClient{
+		clientID:    authCfg.ClientID,
+		tenantID:    authCfg.TenantID,
+		environment: env,
+	}
+
+	// all clients share a sender, so that the request rate is capped across the provider
+	s := autorest.DecorateSender(sender.BuildSender("AzureAD"),
+		ar.WithRateLimit(ar.NewRateLimiter(opts.MaxRequestsPerSecond)),
+		ar.WithRetries(ar.RetryOptions{
+			MaxRetries: opts.MaxRetries,
+			MinBackoff: retryMinBackoff,
+			MaxBackoff: retryMaxBackoff,
+		}),
+	)
+
+	if opts.UseMicrosoftGraph {
+		client.registerMicrosoftGraphClients(graphEndpoint, graphAuthorizer, s)
+	} else {
+		client.registerGraphRBACClients(graphEndpoint, authCfg.TenantID, graphAuthorizer, s)
+	}
+
+	return &client
+}
+
+func (c *ArmClient) registerGraphRBACClients(endpoint, tenantID string, authorizer autorest.Authorizer, s autorest.Sender) {
+	appRoleAssignmentsClient := graph.NewAppRoleAssignmentsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&appRoleAssignmentsClient.Client, authorizer, s)
+	c.appRoleAssignmentsClient = appRoleAssignmentsClient
 
-	return &client, nil
+	applicationsClient := graph.NewApplicationsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&applicationsClient.Client, authorizer, s)
+	c.applicationsClient = applicationsClient
+
+	domainsClient := graphrbac.NewDomainsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&domainsClient.Client, authorizer, s)
+	c.domainsClient = domainsClient
+
+	groupsClient := graph.NewGroupsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&groupsClient.Client, authorizer, s)
+	c.groupsClient = groupsClient
+
+	oauth2Client := graph.NewOAuth2PermissionGrantsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&oauth2Client.Client, authorizer, s)
+	c.oauth2Client = oauth2Client
+
+	objectsClient := graphrbac.NewObjectsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&objectsClient.Client, authorizer, s)
+	c.objectsClient = objectsClient
+
+	servicePrincipalsClient := graphrbac.NewServicePrincipalsClientWithBaseURI(endpoint, tenantID)
+	configureClient(&servicePrincipalsClient.Client, authorizer, s)
+	c.servicePrincipalsClient = servicePrincipalsClient
+
+	usersClient := graphrbac.NewUsersClientWithBaseURI(endpoint, tenantID)
+	configureClient(&usersClient.Client, authorizer, s)
+	c.usersClient = usersClient
 }
 
-func (c *ArmClient) registerGraphRBACClients(endpoint, tenantID string, authorizer autorest.Authorizer) {
-	c.applicationsClient = graphrbac.NewApplicationsClientWithBaseURI(endpoint, tenantID)
-	configureClient(&c.applicationsClient.Client, authorizer)
+func (c *ArmClient) registerMicrosoftGraphClients(endpoint string, authorizer autorest.Authorizer, s autorest.Sender) {
+	appRoleAssignmentsClient := msgraph.NewAppRoleAssignmentsClientWithBaseURI(endpoint)
+	configureClient(&appRoleAssignmentsClient.Client, authorizer, s)
+	c.appRoleAssignmentsClient = appRoleAssignmentsClient
+
+	applicationsClient := msgraph.NewApplicationsClientWithBaseURI(endpoint)
+	configureClient(&applicationsClient.Client, authorizer, s)
+	c.applicationsClient = applicationsClient
+
+	domainsClient := msgraph.NewDomainsClientWithBaseURI(endpoint)
+	configureClient(&domainsClient.Client, authorizer, s)
+	c.domainsClient = domainsClient
 
-	c.domainsClient = graphrbac.NewDomainsClientWithBaseURI(endpoint, tenantID)
-	configureClient(&c.domainsClient.Client, authorizer)
+	groupsClient := msgraph.NewGroupsClientWithBaseURI(endpoint)
+	configureClient(&groupsClient.Client, authorizer, s)
+	c.groupsClient = groupsClient
 
-	c.groupsClient = graphrbac.NewGroupsClientWithBaseURI(endpoint, tenantID)
-	configureClient(&c.groupsClient.Client, authorizer)
+	oauth2Client := msgraph.NewOAuth2PermissionGrantsClientWithBaseURI(endpoint)
+	configureClient(&oauth2Client.Client, authorizer, s)
+	c.oauth2Client = oauth2Client
 
-	c.servicePrincipalsClient = graphrbac.NewServicePrincipalsClientWithBaseURI(endpoint, tenantID)
-	configureClient(&c.servicePrincipalsClient.Client, authorizer)
+	objectsClient := msgraph.NewObjectsClientWithBaseURI(endpoint)
+	configureClient(&objectsClient.Client, authorizer, s)
+	c.objectsClient = objectsClient
 
-	c.usersClient = graphrbac.NewUsersClientWithBaseURI(endpoint, tenantID)
-	configureClient(&c.usersClient.Client, authorizer)
+	servicePrincipalsClient := msgraph.NewServicePrincipalsClientWithBaseURI(endpoint)
+	configureClient(&servicePrincipalsClient.Client, authorizer, s)
+	c.servicePrincipalsClient = servicePrincipalsClient
+
+	usersClient := msgraph.NewUsersClientWithBaseURI(endpoint)
+	configureClient(&usersClient.Client, authorizer, s)
+	c.usersClient = usersClient
 }
 
-func configureClient(client *autorest.Client, auth autorest.Authorizer) {
+func configureClient(client *autorest.Client, auth autorest.Authorizer, s autorest.Sender) {
 	setUserAgent(client)
 	client.Authorizer = auth
-	client.Sender = sender.BuildSender("AzureAD")
+	client.Sender = s
+
+	// retries are handled by the sender, the SDK's own retries would otherwise retry throttled requests indefinitely
+	client.RetryAttempts = 0
+	client.RetryDuration = 0
 	client.SkipResourceProviderRegistration = false
 	client.PollingDuration = 60 * time.Minute
 }
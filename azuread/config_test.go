@@ -0,0 +1,53 @@
+package azuread
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/fakegraph"
+)
+
+func TestArmClient_retriesThrottledRequests(t *testing.T) {
+	server := fakegraph.NewServer("00000000-0000-0000-0000-000000000000")
+	defer server.Close()
+
+	config := &authentication.Config{
+		TenantID: server.TenantID,
+	}
+	client := buildArmClient(config, azure.PublicCloud, server.Endpoint(), autorest.NullAuthorizer{}, ClientOptions{
+		MaxRetries: 1,
+	})
+	ctx := context.Background()
+
+	server.InjectFault(fakegraph.Fault{
+		Path:       regexp.MustCompile("^/domains"),
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 1,
+		Times:      1,
+	})
+
+	start := time.Now()
+	if _, err := client.domainsClient.List(ctx, ""); err != nil {
+		t.Fatalf("Expected the throttled request to be retried: %+v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the retry to honour the Retry-After header, but it took %s", elapsed)
+	}
+
+	server.InjectFault(fakegraph.Fault{
+		Path:       regexp.MustCompile("^/domains"),
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 1,
+	})
+
+	// the SDK would otherwise retry throttled requests indefinitely
+	if _, err := client.domainsClient.List(ctx, ""); err == nil {
+		t.Fatalf("Expected an error once the retries were exhausted")
+	}
+}
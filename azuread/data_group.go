@@ -2,10 +2,11 @@ package azuread
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
@@ -17,10 +18,53 @@ func dataGroup() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"object_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validate.UUID,
+				ConflictsWith: []string{"name"},
+			},
+
 			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validate.NoEmptyStrings,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"object_id"},
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mail_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"security_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"members": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"owners": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 		},
 	}
@@ -30,40 +74,65 @@ func dataSourceActiveDirectoryGroupRead(d *schema.ResourceData, meta interface{}
 	client := meta.(*ArmClient).groupsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	var groupObj *graphrbac.ADGroup
+	var group graphrbac.ADGroup
 
-	// use the name to find the Azure AD group
-	name := d.Get("name").(string)
-	filter := fmt.Sprintf("displayName eq '%s'", name)
-	log.Printf("[DEBUG] Using filter %q", filter)
-
-	resp, err := client.ListComplete(ctx, filter)
-	if err != nil {
-		return fmt.Errorf("Error listing Azure AD groups: %+v", err)
-	}
+	if v, ok := d.GetOk("object_id"); ok {
+		objectId := v.(string)
+		resp, err := client.Get(ctx, objectId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("No Azure AD Group found with Object ID %q", objectId)
+			}
 
-	for _, v := range *resp.Response().Value {
-		if v.DisplayName == nil {
-			continue
+			return fmt.Errorf("Error retrieving Azure AD Group with Object ID %q: %+v", objectId, err)
 		}
 
-		if *v.DisplayName == name {
-			log.Printf("[DEBUG] %q (API result) matches %q (given value)", *v.DisplayName, name)
-			groupObj = &v
-			break
-		} else {
-			log.Printf("[DEBUG] %q (API result) does not match %q (given value)", *v.DisplayName, name)
+		group = resp
+	} else if v, ok := d.GetOk("name"); ok {
+		resp, err := graph.GroupGetByDisplayName(client, ctx, v.(string))
+		if err != nil {
+			return fmt.Errorf("Error finding Azure AD Group: %+v", err)
 		}
-	}
 
-	if groupObj == nil {
-		return fmt.Errorf("Couldn't locate a Azure AD group with a name of %q", name)
+		group = *resp
+	} else {
+		return fmt.Errorf("one of `object_id` or `name` must be specified")
 	}
 
-	if groupObj.ObjectID == nil {
+	if group.ObjectID == nil {
 		return fmt.Errorf("Group objectId is nil")
 	}
-	d.SetId(*groupObj.ObjectID)
+	d.SetId(*group.ObjectID)
+
+	d.Set("object_id", group.ObjectID)
+	d.Set("name", group.DisplayName)
+	d.Set("mail_enabled", group.MailEnabled)
+	d.Set("security_enabled", group.SecurityEnabled)
+
+	// description isn't exposed as a property, so extract it
+	description := ""
+	if v, ok := group.AdditionalProperties["description"].(string); ok {
+		description = v
+	}
+	d.Set("description", description)
+
+	members, err := graph.GroupAllMembers(client, ctx, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("members", members); err != nil {
+		return fmt.Errorf("Error setting `members`: %+v", err)
+	}
+
+	owners, err := graph.GroupAllOwners(client, ctx, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("owners", owners); err != nil {
+		return fmt.Errorf("Error setting `owners`: %+v", err)
+	}
 
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
@@ -35,6 +36,57 @@ func TestAccDataSourceAzureADGroup_byName(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceAzureADGroup_byObjectId(t *testing.T) {
+	dataSourceName := "data.azuread_group.test"
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := testAccDataSourceAzureADGroup_objectId(id)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroup(id),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(dataSourceName),
+					resource.TestCheckResourceAttr(dataSourceName, "name", fmt.Sprintf("acctest%s", id)),
+					resource.TestCheckResourceAttr(dataSourceName, "mail_enabled", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "security_enabled", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "members.#", "0"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "owners.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureADGroup_members(t *testing.T) {
+	dataSourceName := "data.azuread_group.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureADGroup_members(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "members.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceAzureADGroup_name(id string) string {
 	template := testAccAzureADGroup(id)
 	return fmt.Sprintf(`
@@ -45,3 +97,25 @@ data "azuread_group" "test" {
 }
 `, template)
 }
+
+func testAccDataSourceAzureADGroup_objectId(id string) string {
+	template := testAccAzureADGroup(id)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_group" "test" {
+  object_id = "${azuread_group.test.id}"
+}
+`, template)
+}
+
+func testAccDataSourceAzureADGroup_members(id, password string) string {
+	template := testAccAzureADGroupWithMembers(id, password)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_group" "test" {
+  object_id = "${azuread_group.test.id}"
+}
+`, template)
+}
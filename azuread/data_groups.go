@@ -0,0 +1,99 @@
+package azuread
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+func dataGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceActiveDirectoryGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"object_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"names"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
+
+			"names": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"object_ids"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceActiveDirectoryGroupsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).groupsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	objectIds := make([]string, 0)
+	names := make([]string, 0)
+
+	if v, ok := d.GetOk("object_ids"); ok {
+		for _, objectId := range tf.ExpandStringSlice(v.([]interface{})) {
+			group, err := client.Get(ctx, objectId)
+			if err != nil {
+				if ar.ResponseWasNotFound(group.Response) {
+					return fmt.Errorf("No Azure AD Group found with Object ID %q", objectId)
+				}
+
+				return fmt.Errorf("Error retrieving Azure AD Group with Object ID %q: %+v", objectId, err)
+			}
+
+			if group.ObjectID == nil || group.DisplayName == nil {
+				return fmt.Errorf("Group with nil objectId or displayName was returned for Object ID %q", objectId)
+			}
+
+			objectIds = append(objectIds, *group.ObjectID)
+			names = append(names, *group.DisplayName)
+		}
+	} else if v, ok := d.GetOk("names"); ok {
+		for _, name := range tf.ExpandStringSlice(v.([]interface{})) {
+			group, err := graph.GroupGetByDisplayName(client, ctx, name)
+			if err != nil {
+				return fmt.Errorf("Error finding Azure AD Group: %+v", err)
+			}
+
+			if group.ObjectID == nil {
+				return fmt.Errorf("Group objectId is nil")
+			}
+
+			objectIds = append(objectIds, *group.ObjectID)
+			names = append(names, name)
+		}
+	} else {
+		return fmt.Errorf("one of `object_ids` or `names` must be specified")
+	}
+
+	d.SetId(fmt.Sprintf("groups#%d", hashcode.String(strings.Join(objectIds, "/"))))
+
+	if err := d.Set("object_ids", objectIds); err != nil {
+		return fmt.Errorf("Error setting `object_ids`: %+v", err)
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("Error setting `names`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureADGroups_byNames(t *testing.T) {
+	dataSourceName := "data.azuread_groups.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureADGroups_byNames(id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "object_ids.0", "azuread_group.testA", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "object_ids.1", "azuread_group.testB", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureADGroups_byObjectIds(t *testing.T) {
+	dataSourceName := "data.azuread_groups.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureADGroups_byObjectIds(id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "names.0", "azuread_group.testA", "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "names.1", "azuread_group.testB", "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureADGroups_template(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "testA" {
+  name = "acctestA%[1]s"
+}
+
+resource "azuread_group" "testB" {
+  name = "acctestB%[1]s"
+}
+`, id)
+}
+
+func testAccDataSourceAzureADGroups_byNames(id string) string {
+	template := testAccDataSourceAzureADGroups_template(id)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_groups" "test" {
+  names = ["${azuread_group.testA.name}", "${azuread_group.testB.name}"]
+}
+`, template)
+}
+
+func testAccDataSourceAzureADGroups_byObjectIds(id string) string {
+	template := testAccDataSourceAzureADGroups_template(id)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_groups" "test" {
+  object_ids = ["${azuread_group.testA.id}", "${azuread_group.testB.id}"]
+}
+`, template)
+}
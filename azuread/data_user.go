@@ -7,6 +7,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
@@ -43,6 +44,87 @@ func dataSourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"given_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"surname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"job_title": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"department": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"company_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"office_location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"usage_location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mobile_phone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"street_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"city": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"country": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"postal_code": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"immutable_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"other_mails": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -75,5 +157,18 @@ func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("mail", user.Mail)
 	d.Set("mail_nickname", user.MailNickname)
 
+	values, otherMails, err := graph.UserPropertyValues(user)
+	if err != nil {
+		return fmt.Errorf("Error flattening User with ID %q: %+v", *user.ObjectID, err)
+	}
+
+	for key, value := range values {
+		d.Set(key, value)
+	}
+
+	if err := d.Set("other_mails", otherMails); err != nil {
+		return fmt.Errorf("Error setting `other_mails`: %+v", err)
+	}
+
 	return nil
 }
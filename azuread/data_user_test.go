@@ -31,6 +31,31 @@ func TestAccDataSourceAzureADUser_byUserPrincipalName(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceAzureADUser_profile(t *testing.T) {
+	dataSourceName := "data.azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADUserDataSource_profile(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "given_name", "Ada"),
+					resource.TestCheckResourceAttr(dataSourceName, "surname", "Lovelace"),
+					resource.TestCheckResourceAttr(dataSourceName, "company_name", "Analytical Engines Ltd"),
+					resource.TestCheckResourceAttr(dataSourceName, "office_location", "Building 1"),
+					resource.TestCheckResourceAttr(dataSourceName, "mobile_phone", "+44 20 7946 0000"),
+					resource.TestCheckResourceAttr(dataSourceName, "user_type", "Guest"),
+					resource.TestCheckResourceAttr(dataSourceName, "other_mails.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccAzureADUserDataSource_byUserPrincipalName(id, password string) string {
 	template := testAccADUser_basic(id, password)
 	return fmt.Sprintf(`
@@ -42,3 +67,15 @@ data "azuread_user" "test" {
 }
 `, template)
 }
+
+func testAccAzureADUserDataSource_profile(id, password string) string {
+	template := testAccADUser_profile(id, password)
+	return fmt.Sprintf(`
+
+%s
+
+data "azuread_user" "test" {
+	user_principal_name = "${azuread_user.test.user_principal_name}"
+}
+`, template)
+}
@@ -0,0 +1,139 @@
+package azuread
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUsersRead,
+
+		Schema: map[string]*schema.Schema{
+			"object_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"user_principal_names", "mail_nicknames", "filter"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
+
+			"user_principal_names": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"object_ids", "mail_nicknames", "filter"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"mail_nicknames": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"object_ids", "user_principal_names", "filter"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"filter": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"object_ids", "user_principal_names", "mail_nicknames"},
+				ValidateFunc:  validate.NoEmptyStrings,
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).usersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	var users []graphrbac.User
+
+	if v, ok := d.GetOk("filter"); ok {
+		filter := v.(string)
+
+		results, err := graph.UsersListByFilter(client, ctx, filter)
+		if err != nil {
+			return err
+		}
+		users = results
+	} else {
+		var property, argument string
+		var values []string
+
+		if v, ok := d.GetOk("object_ids"); ok {
+			property, argument, values = "objectId", "object_ids", tf.ExpandStringSlice(v.([]interface{}))
+		} else if v, ok := d.GetOk("user_principal_names"); ok {
+			property, argument, values = "userPrincipalName", "user_principal_names", tf.ExpandStringSlice(v.([]interface{}))
+		} else if v, ok := d.GetOk("mail_nicknames"); ok {
+			property, argument, values = "mailNickname", "mail_nicknames", tf.ExpandStringSlice(v.([]interface{}))
+		} else {
+			return fmt.Errorf("one of `object_ids`, `user_principal_names`, `mail_nicknames` or `filter` must be specified")
+		}
+
+		results, err := graph.UsersFindByProperty(client, ctx, property, values)
+		if err != nil {
+			return err
+		}
+
+		// preserve the order of the values we were given so that the lists line up
+		for _, v := range values {
+			user, ok := results[strings.ToLower(v)]
+			if !ok {
+				return fmt.Errorf("Error: No AzureAD User found with %s %q (from `%s`)", property, v, argument)
+			}
+			users = append(users, user)
+		}
+	}
+
+	objectIds := make([]string, 0, len(users))
+	upns := make([]string, 0, len(users))
+	mailNicknames := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.ObjectID == nil || u.UserPrincipalName == nil {
+			return fmt.Errorf("User with nil objectId or userPrincipalName was returned")
+		}
+
+		objectIds = append(objectIds, *u.ObjectID)
+		upns = append(upns, *u.UserPrincipalName)
+
+		mailNickname := ""
+		if u.MailNickname != nil {
+			mailNickname = *u.MailNickname
+		}
+		mailNicknames = append(mailNicknames, mailNickname)
+	}
+
+	d.SetId(fmt.Sprintf("users#%d", hashcode.String(strings.Join(objectIds, "/"))))
+
+	if err := d.Set("object_ids", objectIds); err != nil {
+		return fmt.Errorf("Error setting `object_ids`: %+v", err)
+	}
+
+	if err := d.Set("user_principal_names", upns); err != nil {
+		return fmt.Errorf("Error setting `user_principal_names`: %+v", err)
+	}
+
+	if err := d.Set("mail_nicknames", mailNicknames); err != nil {
+		return fmt.Errorf("Error setting `mail_nicknames`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureADUsers_byUserPrincipalNames(t *testing.T) {
+	dataSourceName := "data.azuread_users.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADUsersDataSource_byUserPrincipalNames(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "user_principal_names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "mail_nicknames.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "object_ids.0", "azuread_user.testA", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "object_ids.1", "azuread_user.testB", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureADUsers_byObjectIds(t *testing.T) {
+	dataSourceName := "data.azuread_users.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADUsersDataSource_byObjectIds(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "user_principal_names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "user_principal_names.0", "azuread_user.testA", "user_principal_name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "user_principal_names.1", "azuread_user.testB", "user_principal_name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureADUsers_byMailNicknames(t *testing.T) {
+	dataSourceName := "data.azuread_users.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADUsersDataSource_byMailNicknames(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "user_principal_names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureADUsers_byFilter(t *testing.T) {
+	dataSourceName := "data.azuread_users.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// the users must exist before the data source is read, since depending on them forces a diff
+				Config: testAccAzureADUsersDataSource_template(id, password),
+			},
+			{
+				Config: testAccAzureADUsersDataSource_byFilter(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "user_principal_names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "object_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAzureADUsersDataSource_template(id, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "testA" {
+  user_principal_name = "acctest%[1]s-A@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[1]s-A"
+  mail_nickname       = "acctest%[1]s-A"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "testB" {
+  user_principal_name = "acctest%[1]s-B@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[1]s-B"
+  mail_nickname       = "acctest%[1]s-B"
+  password            = "%[2]s"
+}
+`, id, password)
+}
+
+func testAccAzureADUsersDataSource_byUserPrincipalNames(id, password string) string {
+	template := testAccAzureADUsersDataSource_template(id, password)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_users" "test" {
+  user_principal_names = ["${azuread_user.testA.user_principal_name}", "${azuread_user.testB.user_principal_name}"]
+}
+`, template)
+}
+
+func testAccAzureADUsersDataSource_byObjectIds(id, password string) string {
+	template := testAccAzureADUsersDataSource_template(id, password)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_users" "test" {
+  object_ids = ["${azuread_user.testA.id}", "${azuread_user.testB.id}"]
+}
+`, template)
+}
+
+func testAccAzureADUsersDataSource_byMailNicknames(id, password string) string {
+	template := testAccAzureADUsersDataSource_template(id, password)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_users" "test" {
+  mail_nicknames = ["${azuread_user.testA.mail_nickname}", "${azuread_user.testB.mail_nickname}"]
+}
+`, template)
+}
+
+func testAccAzureADUsersDataSource_byFilter(id, password string) string {
+	template := testAccAzureADUsersDataSource_template(id, password)
+	return fmt.Sprintf(`
+%s
+
+data "azuread_users" "test" {
+  filter = "startswith(displayName,'acctest%s-')"
+}
+`, template, id)
+}
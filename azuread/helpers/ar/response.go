@@ -1,8 +1,11 @@
 package ar
 
 import (
+	"errors"
 	"net"
 	"net/http"
+	"strings"
+	"syscall"
 
 	"github.com/Azure/go-autorest/autorest"
 )
@@ -12,11 +15,48 @@ func ResponseWasNotFound(resp autorest.Response) bool {
 	return responseWasStatusCode(resp, http.StatusNotFound)
 }
 
+// replicationDelayMessages are the errors returned by Graph when a request references an object which exists but has
+// not yet replicated to the replica serving the request
+var replicationDelayMessages = []string{
+	"does not exist or one of its queried reference-property objects are not present",
+	"does not reference a valid application object",
+}
+
+// ResponseWasReplicationDelay returns whether a request failed because an object it references has not yet replicated,
+// which Graph reports either as not found or as a bad request
+func ResponseWasReplicationDelay(resp autorest.Response, err error) bool {
+	if ResponseWasNotFound(resp) {
+		return true
+	}
+
+	if err == nil || !responseWasStatusCode(resp, http.StatusBadRequest) {
+		return false
+	}
+
+	for _, message := range replicationDelayMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResponseErrorIsRetryable returns whether an error is transient, either a temporary network error, a reset connection
+// or a request which was throttled or rejected because the service was unavailable
 func ResponseErrorIsRetryable(err error) bool {
 	if arerr, ok := err.(autorest.DetailedError); ok {
+		if code, ok := arerr.StatusCode.(int); ok && (code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable) {
+			return true
+		}
 		err = arerr.Original
 	}
 
+	// a reset connection is transient, but isn't reported as a temporary error
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
 	switch e := err.(type) {
 	case net.Error:
 		if e.Temporary() || e.Timeout() {
@@ -27,7 +67,7 @@ func ResponseErrorIsRetryable(err error) bool {
 	return false
 }
 
-func responseWasStatusCode(resp autorest.Response, statusCode int) bool { // nolint: unparam
+func responseWasStatusCode(resp autorest.Response, statusCode int) bool {
 	if r := resp.Response; r != nil {
 		if r.StatusCode == statusCode {
 			return true
@@ -2,7 +2,10 @@ package ar
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -64,6 +67,13 @@ func TestResponseErrorIsRetryable(t *testing.T) {
 			Original: testNetError{true, true}}, true},
 		{"Unhandled error nested in autorest.DetailedError is not retryable", autorest.DetailedError{
 			Original: fmt.Errorf("Some other error")}, false},
+		{"Throttled requests are retryable", autorest.DetailedError{
+			StatusCode: http.StatusTooManyRequests}, true},
+		{"Requests rejected while the service is unavailable are retryable", autorest.DetailedError{
+			StatusCode: http.StatusServiceUnavailable}, true},
+		{"Other failed requests are not retryable", autorest.DetailedError{
+			StatusCode: http.StatusBadRequest}, false},
+		{"Reset connections are retryable", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
 		{"nil is handled as non-retryable", nil, false},
 	}
 
@@ -75,3 +85,30 @@ func TestResponseErrorIsRetryable(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseWasReplicationDelay(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		statusCode     int
+		err            error
+		expectedResult bool
+	}{
+		{"Not found is a replication delay", http.StatusNotFound, fmt.Errorf("Request_ResourceNotFound"), true},
+		{"Bad request referencing an unreplicated object is a replication delay", http.StatusBadRequest, fmt.Errorf("Resource 'x' does not exist or one of its queried reference-property objects are not present."), true},
+		{"Bad request referencing an unreplicated application is a replication delay", http.StatusBadRequest, fmt.Errorf("The appId 'x' of the service principal does not reference a valid application object."), true},
+		{"Other bad requests are not a replication delay", http.StatusBadRequest, fmt.Errorf("Property identifierUris is invalid."), false},
+		{"Server errors are not a replication delay", http.StatusInternalServerError, fmt.Errorf("does not reference a valid application object"), false},
+	}
+
+	for _, test := range testCases {
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: test.statusCode,
+			},
+		}
+		result := ResponseWasReplicationDelay(resp, test.err)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for '%s' - got '%+v'", test.expectedResult, test.desc, result)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package ar
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// RetryOptions configures how requests which fail with a transient error are retried
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried before giving up
+	MaxRetries int
+
+	// MinBackoff is the upper bound of the first delay between attempts, which doubles on each retry
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts when the response doesn't include a Retry-After header
+	MaxBackoff time.Duration
+}
+
+// WithRetries returns a SendDecorator which retries requests which were throttled or failed with a transient server
+// or network error. Server and network errors are only retried when repeating the request is harmless, so that an
+// object is never created twice. Requests referencing an object which hasn't yet replicated are left to
+// graph.RetryOnReplicationDelay, since Graph reports them with the same errors as a reference to an object which
+// doesn't exist at all, which only the caller can give up on within its timeout. Requests are retried after the delay given
+// in the Retry-After header where present, otherwise after a jittered exponential backoff.
+//
+// Once the retries are exhausted an error is returned rather than the response, so that the retries built into the
+// SDK clients (which retry throttled requests indefinitely) don't start over.
+func WithRetries(opts RetryOptions) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			rr := autorest.NewRetriableRequest(r)
+
+			for attempt := 0; ; attempt++ {
+				if err := rr.Prepare(); err != nil {
+					return nil, err
+				}
+
+				resp, err := s.Do(rr.Request())
+				if !requestIsRetryable(r.Method, resp, err) {
+					return resp, err
+				}
+
+				if attempt >= opts.MaxRetries {
+					if err != nil {
+						return resp, err
+					}
+					return nil, fmt.Errorf("giving up on %s %s after %d retries: %s", r.Method, r.URL, attempt, describeResponse(resp))
+				}
+
+				delay := retryAfter(resp)
+				if delay == 0 {
+					delay = backoff(opts, attempt)
+				}
+
+				if err != nil {
+					log.Printf("[DEBUG] Retrying %s %s in %s after error: %+v", r.Method, r.URL, delay, err)
+				} else {
+					log.Printf("[DEBUG] Retrying %s %s in %s after %s", r.Method, r.URL, delay, resp.Status)
+					drainResponse(resp)
+				}
+
+				select {
+				case <-time.After(delay):
+				case <-r.Context().Done():
+					return nil, r.Context().Err()
+				}
+			}
+		})
+	}
+}
+
+// RateLimiter spaces out requests so that no more than the given number are sent per second, and is shared between
+// all of the clients of a provider
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing the given number of requests per second, zero means unlimited
+func NewRateLimiter(requestsPerSecond int) *RateLimiter {
+	l := &RateLimiter{}
+	if requestsPerSecond > 0 {
+		l.interval = time.Second / time.Duration(requestsPerSecond)
+	}
+
+	return l
+}
+
+// WithRateLimit returns a SendDecorator which waits for the RateLimiter before sending each request
+func WithRateLimit(l *RateLimiter) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if delay := l.reserve(); delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-r.Context().Done():
+					return nil, r.Context().Err()
+				}
+			}
+
+			return s.Do(r)
+		})
+	}
+}
+
+// reserve returns how long the caller needs to wait before sending its request
+func (l *RateLimiter) reserve() time.Duration {
+	if l == nil || l.interval == 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return delay
+}
+
+func requestIsRetryable(method string, resp *http.Response, err error) bool {
+	// the request may have been processed before the connection failed, so only retry it when repeating it has the
+	// same effect or the connection was never established
+	if err != nil {
+		return resp == nil && ResponseErrorIsRetryable(err) && (methodIsIdempotent(method) || errorIsDial(err))
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	// the request wasn't processed, so it's always safe to try again
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+
+	// the request may have been processed, so only retry it when repeating it has the same effect
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return methodIsIdempotent(method)
+	}
+
+	return false
+}
+
+func methodIsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// errorIsDial returns whether a network error occurred while connecting, before any of the request was sent
+func errorIsDial(err error) bool {
+	if arerr, ok := err.(autorest.DetailedError); ok {
+		err = arerr.Original
+	}
+
+	if uerr, ok := err.(*url.Error); ok {
+		err = uerr.Err
+	}
+
+	operr, ok := err.(*net.OpError)
+	return ok && operr.Op == "dial"
+}
+
+func peekBody(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close() // nolint: errcheck
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	return string(body)
+}
+
+func drainResponse(resp *http.Response) {
+	if resp.Body != nil {
+		ioutil.ReadAll(resp.Body) // nolint: errcheck
+		resp.Body.Close()         // nolint: errcheck
+	}
+}
+
+func describeResponse(resp *http.Response) string {
+	body := strings.TrimSpace(peekBody(resp))
+	if body == "" {
+		return resp.Status
+	}
+
+	return fmt.Sprintf("%s: %s", resp.Status, body)
+}
+
+// retryAfter returns the delay requested by the Retry-After header, which is either a number of seconds or a date
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// backoff returns a random delay between zero and the exponentially increasing cap for the attempt ("full jitter"),
+// which spreads out the retries of concurrent requests which were throttled at the same time
+func backoff(opts RetryOptions, attempt int) time.Duration {
+	limit := float64(opts.MinBackoff) * math.Pow(2, float64(attempt))
+	if opts.MaxBackoff > 0 && limit > float64(opts.MaxBackoff) {
+		limit = float64(opts.MaxBackoff)
+	}
+
+	if limit < 1 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(limit)))
+}
@@ -0,0 +1,222 @@
+package ar
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type testResponse struct {
+	statusCode int
+	retryAfter string
+	body       string
+}
+
+// testSender returns the given responses in order, repeating the last one once they run out
+func testSender(responses ...testResponse) (autorest.Sender, *int) {
+	count := 0
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		tr := responses[len(responses)-1]
+		if count < len(responses) {
+			tr = responses[count]
+		}
+		count++
+
+		resp := &http.Response{
+			Status:     http.StatusText(tr.statusCode),
+			StatusCode: tr.statusCode,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(tr.body)),
+			Request:    r,
+		}
+		if tr.retryAfter != "" {
+			resp.Header.Set("Retry-After", tr.retryAfter)
+		}
+		return resp, nil
+	}), &count
+}
+
+func testRequest(t *testing.T, method string) *http.Request {
+	var body io.Reader
+	if method == http.MethodPost || method == http.MethodPatch {
+		body = strings.NewReader(`{"displayName":"test"}`)
+	}
+
+	req, err := http.NewRequest(method, "https://graph.example.com/tenant/applications", body)
+	if err != nil {
+		t.Fatalf("Error building request: %+v", err)
+	}
+
+	return req
+}
+
+var testRetryOptions = RetryOptions{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 10 * time.Millisecond,
+}
+
+func TestWithRetries(t *testing.T) {
+	replicationBody := `{"odata.error":{"message":{"value":"Resource 'x' does not exist or one of its queried reference-property objects are not present."}}}`
+
+	testCases := []struct {
+		desc           string
+		method         string
+		responses      []testResponse
+		expectedStatus int
+		expectedCalls  int
+		expectedError  bool
+	}{
+		{"Successful requests aren't retried", http.MethodGet, []testResponse{{statusCode: http.StatusOK}}, http.StatusOK, 1, false},
+		{"Throttled requests are retried", http.MethodPost, []testResponse{{statusCode: http.StatusTooManyRequests}, {statusCode: http.StatusCreated}}, http.StatusCreated, 2, false},
+		{"Unavailable requests are retried", http.MethodPost, []testResponse{{statusCode: http.StatusServiceUnavailable}, {statusCode: http.StatusCreated}}, http.StatusCreated, 2, false},
+		{"Server errors are retried for idempotent methods", http.MethodGet, []testResponse{{statusCode: http.StatusInternalServerError}, {statusCode: http.StatusOK}}, http.StatusOK, 2, false},
+		{"Server errors aren't retried when creating objects", http.MethodPost, []testResponse{{statusCode: http.StatusInternalServerError}, {statusCode: http.StatusCreated}}, http.StatusInternalServerError, 1, false},
+		{"Bad requests referencing unreplicated objects are left to the caller", http.MethodPost, []testResponse{{statusCode: http.StatusBadRequest, body: replicationBody}, {statusCode: http.StatusNoContent}}, http.StatusBadRequest, 1, false},
+		{"Other bad requests aren't retried", http.MethodPost, []testResponse{{statusCode: http.StatusBadRequest, body: `{"odata.error":{}}`}, {statusCode: http.StatusNoContent}}, http.StatusBadRequest, 1, false},
+		{"Writes referencing missing objects are left to the caller", http.MethodPatch, []testResponse{{statusCode: http.StatusNotFound, body: replicationBody}, {statusCode: http.StatusNoContent}}, http.StatusNotFound, 1, false},
+		{"Objects which aren't found when read aren't retried", http.MethodGet, []testResponse{{statusCode: http.StatusNotFound, body: replicationBody}, {statusCode: http.StatusOK}}, http.StatusNotFound, 1, false},
+		{"Retries are limited", http.MethodGet, []testResponse{{statusCode: http.StatusTooManyRequests}}, 0, 4, true},
+	}
+
+	for _, test := range testCases {
+		s, calls := testSender(test.responses...)
+		resp, err := autorest.DecorateSender(s, WithRetries(testRetryOptions)).Do(testRequest(t, test.method))
+
+		if test.expectedError != (err != nil) {
+			t.Errorf("Expected error %t for case '%s' - got %+v", test.expectedError, test.desc, err)
+		}
+		if !test.expectedError && (resp == nil || resp.StatusCode != test.expectedStatus) {
+			t.Errorf("Expected status %d for case '%s' - got %+v", test.expectedStatus, test.desc, resp)
+		}
+		if *calls != test.expectedCalls {
+			t.Errorf("Expected %d requests for case '%s' - got %d", test.expectedCalls, test.desc, *calls)
+		}
+	}
+}
+
+func TestWithRetries_networkErrors(t *testing.T) {
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	dialTimeout := &net.OpError{Op: "dial", Net: "tcp", Err: testNetError{timeout: true}}
+
+	testCases := []struct {
+		desc          string
+		method        string
+		err           error
+		expectedCalls int
+		expectedError bool
+	}{
+		{"Reset connections are retried for idempotent methods", http.MethodGet, reset, 2, false},
+		{"Reset connections aren't retried when creating objects", http.MethodPost, reset, 1, true},
+		{"Connections which time out before being established are retried when creating objects", http.MethodPost, dialTimeout, 2, false},
+	}
+
+	for _, test := range testCases {
+		calls := 0
+		s := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, test.err
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		})
+
+		_, err := autorest.DecorateSender(s, WithRetries(testRetryOptions)).Do(testRequest(t, test.method))
+
+		if test.expectedError != (err != nil) {
+			t.Errorf("Expected error %t for case '%s' - got %+v", test.expectedError, test.desc, err)
+		}
+		if calls != test.expectedCalls {
+			t.Errorf("Expected %d requests for case '%s' - got %d", test.expectedCalls, test.desc, calls)
+		}
+	}
+}
+
+func TestWithRetries_bodyIsResent(t *testing.T) {
+	bodies := make([]string, 0)
+	s := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		statusCode := http.StatusTooManyRequests
+		if len(bodies) > 1 {
+			statusCode = http.StatusCreated
+		}
+		return &http.Response{StatusCode: statusCode, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	if _, err := autorest.DecorateSender(s, WithRetries(testRetryOptions)).Do(testRequest(t, http.MethodPost)); err != nil {
+		t.Fatalf("Expected the request to succeed: %+v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Fatalf("Expected the body to be sent with each attempt - got %q", bodies)
+	}
+}
+
+func TestWithRetries_honoursRetryAfter(t *testing.T) {
+	s, _ := testSender(testResponse{statusCode: http.StatusTooManyRequests, retryAfter: "1"}, testResponse{statusCode: http.StatusOK})
+
+	start := time.Now()
+	if _, err := autorest.DecorateSender(s, WithRetries(testRetryOptions)).Do(testRequest(t, http.MethodGet)); err != nil {
+		t.Fatalf("Expected the request to succeed: %+v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the retry to wait for the Retry-After header, but it took %s", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	opts := RetryOptions{
+		MinBackoff: time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		limit := time.Second << uint(attempt)
+		if limit > opts.MaxBackoff {
+			limit = opts.MaxBackoff
+		}
+
+		for i := 0; i < 100; i++ {
+			if delay := backoff(opts, attempt); delay < 0 || delay >= limit {
+				t.Fatalf("Expected the delay for attempt %d to be less than %s - got %s", attempt, limit, delay)
+			}
+		}
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	s, calls := testSender(testResponse{statusCode: http.StatusOK})
+	sender := autorest.DecorateSender(s, WithRateLimit(NewRateLimiter(20)))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := sender.Do(testRequest(t, http.MethodGet)); err != nil {
+			t.Fatalf("Expected the request to succeed: %+v", err)
+		}
+	}
+
+	// the first request is sent immediately, then one every 50ms
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("Expected 5 requests at 20 per second to take at least 200ms - took %s", elapsed)
+	}
+	if *calls != 5 {
+		t.Fatalf("Expected 5 requests - got %d", *calls)
+	}
+}
+
+func TestWithRateLimit_unlimited(t *testing.T) {
+	if delay := NewRateLimiter(0).reserve(); delay != 0 {
+		t.Fatalf("Expected no delay without a limit - got %s", delay)
+	}
+}
@@ -20,10 +20,9 @@ type azureCliTokenAuth struct {
 func (a azureCliTokenAuth) build(b Builder) (authMethod, error) {
 	auth := azureCliTokenAuth{
 		profile: &azureCLIProfile{
-			clientId:       b.ClientID,
-			environment:    b.Environment,
-			subscriptionId: b.SubscriptionID,
-			tenantId:       b.TenantID,
+			clientId:    b.ClientID,
+			environment: b.Environment,
+			tenantId:    b.TenantID,
 		},
 	}
 	profilePath, err := cli.ProfilePath()
@@ -57,7 +56,13 @@ func (a azureCliTokenAuth) isApplicable(b Builder) bool {
 
 func (a azureCliTokenAuth) getAuthorizationToken(oauthConfig *adal.OAuthConfig, endpoint string) (*autorest.BearerAuthorizer, error) {
 	// the Azure CLI appears to cache these, so to maintain compatibility with the interface this method is intentionally not on the pointer
-	token, err := obtainAuthorizationToken(endpoint, a.profile.subscriptionId)
+	// the token is requested for the tenant of the OAuth config, which differs from the profile for auxiliary tenants
+	tenantId := a.profile.tenantId
+	if segments := strings.Split(strings.Trim(oauthConfig.TokenEndpoint.Path, "/"), "/"); segments[0] != "" {
+		tenantId = segments[0]
+	}
+
+	token, err := obtainAuthorizationToken(endpoint, tenantId)
 	if err != nil {
 		return nil, fmt.Errorf("Error obtaining Authorization Token from the Azure CLI: %s", err)
 	}
@@ -83,7 +88,6 @@ func (a azureCliTokenAuth) name() string {
 func (a azureCliTokenAuth) populateConfig(c *Config) error {
 	c.ClientID = a.profile.clientId
 	c.Environment = a.profile.environment
-	c.SubscriptionID = a.profile.subscriptionId
 	c.TenantID = a.profile.tenantId
 	return nil
 }
@@ -101,10 +105,6 @@ func (a azureCliTokenAuth) validate() error {
 		err = multierror.Append(err, fmt.Errorf(errorMessageFmt, "Client ID"))
 	}
 
-	if a.profile.subscriptionId == "" {
-		err = multierror.Append(err, fmt.Errorf(errorMessageFmt, "Subscription ID"))
-	}
-
 	if a.profile.tenantId == "" {
 		err = multierror.Append(err, fmt.Errorf(errorMessageFmt, "Tenant ID"))
 	}
@@ -112,11 +112,13 @@ func (a azureCliTokenAuth) validate() error {
 	return err.ErrorOrNil()
 }
 
-func obtainAuthorizationToken(endpoint string, subscriptionId string) (*cli.Token, error) {
+// obtainAuthorizationToken requests a token for the tenant rather than for a subscription, since the tenant may not
+// have any subscriptions
+func obtainAuthorizationToken(endpoint string, tenantId string) (*cli.Token, error) {
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 
-	cmd := exec.Command("az", "account", "get-access-token", "--resource", endpoint, "--subscription", subscriptionId, "-o=json")
+	cmd := exec.Command("az", "account", "get-access-token", "--resource", endpoint, "--tenant", tenantId, "-o=json")
 
 	cmd.Stderr = &stderr
 	cmd.Stdout = &stdout
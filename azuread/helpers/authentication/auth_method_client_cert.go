@@ -0,0 +1,219 @@
+package authentication
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/pkcs12"
+)
+
+type servicePrincipalClientCertificateAuth struct {
+	clientId           string
+	clientCertPath     string
+	clientCert         string
+	clientCertKey      string
+	clientCertPassword string
+	tenantId           string
+}
+
+func (a servicePrincipalClientCertificateAuth) build(b Builder) (authMethod, error) {
+	method := servicePrincipalClientCertificateAuth{
+		clientId:           b.ClientID,
+		clientCertPath:     b.ClientCertPath,
+		clientCert:         b.ClientCert,
+		clientCertKey:      b.ClientCertKey,
+		clientCertPassword: b.ClientCertPassword,
+		tenantId:           b.TenantID,
+	}
+	return method, nil
+}
+
+func (a servicePrincipalClientCertificateAuth) isApplicable(b Builder) bool {
+	return b.SupportsClientCertAuth && (b.ClientCertPath != "" || b.ClientCert != "")
+}
+
+func (a servicePrincipalClientCertificateAuth) name() string {
+	return "Service Principal / Client Certificate"
+}
+
+func (a servicePrincipalClientCertificateAuth) getAuthorizationToken(oauthConfig *adal.OAuthConfig, endpoint string) (*autorest.BearerAuthorizer, error) {
+	certificateData, err := a.certificateData()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, rsaPrivateKey, err := decodeCertificate(certificateData, []byte(a.clientCertKey), a.clientCertPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	spt, err := adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, a.clientId, certificate, rsaPrivateKey, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	err = spt.Refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	auth := autorest.NewBearerAuthorizer(spt)
+	return auth, nil
+}
+
+// certificateData returns the PEM or PKCS#12 encoded certificate, either from the file or from the base64 encoded
+// content when it's not PEM
+func (a servicePrincipalClientCertificateAuth) certificateData() ([]byte, error) {
+	if a.clientCertPath != "" {
+		data, err := ioutil.ReadFile(a.clientCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading Client Certificate %q: %v", a.clientCertPath, err)
+		}
+		return data, nil
+	}
+
+	if isPEM([]byte(a.clientCert)) {
+		return []byte(a.clientCert), nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(a.clientCert))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding Client Certificate: it must be PEM encoded, or a base64 encoded PKCS#12 (PFX) file: %v", err)
+	}
+	return data, nil
+}
+
+func (a servicePrincipalClientCertificateAuth) populateConfig(c *Config) error {
+	c.AuthenticatedAsAServicePrincipal = true
+	return nil
+}
+
+func (a servicePrincipalClientCertificateAuth) validate() error {
+	var err *multierror.Error
+
+	fmtErrorMessage := "A %s must be configured when authenticating as a Service Principal using a Client Certificate."
+
+	if a.clientId == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Client ID"))
+	}
+
+	if a.clientCertPath != "" && a.clientCert != "" {
+		err = multierror.Append(err, fmt.Errorf("Only one of the Client Certificate Path or the Client Certificate should be configured."))
+	} else if a.clientCertPath != "" {
+		path := strings.ToLower(a.clientCertPath)
+		if strings.HasSuffix(path, ".pfx") || strings.HasSuffix(path, ".pem") {
+			// ensure it exists on disk
+			_, fileErr := os.Stat(a.clientCertPath)
+			if os.IsNotExist(fileErr) {
+				err = multierror.Append(err, fmt.Errorf("Error locating Client Certificate specified at %q: %s", a.clientCertPath, fileErr))
+			}
+
+			// we're intentionally /not/ checking it's an actual certificate at this point, as that happens in the getAuthorizationToken
+		} else {
+			err = multierror.Append(err, fmt.Errorf("The Client Certificate Path is not a *.pfx or *.pem file: %q", a.clientCertPath))
+		}
+	}
+
+	if a.tenantId == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Tenant ID"))
+	}
+
+	return err.ErrorOrNil()
+}
+
+func isPEM(data []byte) bool {
+	return bytes.Contains(data, []byte("-----BEGIN "))
+}
+
+// decodeCertificate returns the certificate and private key from either a PKCS#12 (PFX) file, or PEM encoded data
+// which contains the certificate and optionally the private key - which otherwise must be specified separately
+func decodeCertificate(data, keyData []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	if !isPEM(data) {
+		certificate, privateKey, err := decodePkcs12(data, password)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error decoding pkcs12 certificate: %v", err)
+		}
+		return certificate, privateKey, nil
+	}
+
+	var certificate *x509.Certificate
+	var privateKey *rsa.PrivateKey
+
+	for _, d := range [][]byte{data, keyData} {
+		for block, rest := pem.Decode(d); block != nil; block, rest = pem.Decode(rest) {
+			switch {
+			case block.Type == "CERTIFICATE" && certificate == nil:
+				c, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, nil, fmt.Errorf("Error parsing PEM certificate: %v", err)
+				}
+				certificate = c
+
+			case strings.HasSuffix(block.Type, "PRIVATE KEY") && privateKey == nil:
+				k, err := parsePrivateKey(block)
+				if err != nil {
+					return nil, nil, err
+				}
+				privateKey = k
+			}
+		}
+	}
+
+	if certificate == nil {
+		return nil, nil, fmt.Errorf("No certificate was found in the PEM encoded Client Certificate")
+	}
+	if privateKey == nil {
+		return nil, nil, fmt.Errorf("No private key was found in the PEM encoded Client Certificate or Client Certificate Key")
+	}
+
+	return certificate, privateKey, nil
+}
+
+func parsePrivateKey(block *pem.Block) (*rsa.PrivateKey, error) {
+	if _, encrypted := block.Headers["DEK-Info"]; encrypted || block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, fmt.Errorf("Encrypted PEM private keys are not supported, please decrypt the key or use a PKCS#12 (PFX) certificate")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing PEM private key: %v", err)
+		}
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing PEM private key: %v", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("PEM certificate must contain an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+func decodePkcs12(pkcs []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	privateKey, certificate, err := pkcs12.Decode(pkcs, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rsaPrivateKey, isRsaKey := privateKey.(*rsa.PrivateKey)
+	if !isRsaKey {
+		return nil, nil, fmt.Errorf("PKCS#12 certificate must contain an RSA private key")
+	}
+
+	return certificate, rsaPrivateKey, nil
+}
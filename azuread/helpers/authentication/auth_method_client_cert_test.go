@@ -0,0 +1,239 @@
+package authentication
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a PEM encoded self-signed certificate, and its PKCS#1 and PKCS#8 encoded private keys
+func testCertificate(t *testing.T) (string, string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %+v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-azuread-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %+v", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error encoding key: %+v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+}
+
+func TestClientCertificateAuth_content(t *testing.T) {
+	certificate, pkcs1Key, pkcs8Key := testCertificate(t)
+
+	pfx, err := ioutil.ReadFile("testdata/certificate.pfx")
+	if err != nil {
+		t.Fatalf("Error reading PFX: %+v", err)
+	}
+
+	cases := []struct {
+		name        string
+		certificate string
+		key         string
+		password    string
+		expectError bool
+	}{
+		{
+			name:        "PEM with PKCS#1 key",
+			certificate: certificate + pkcs1Key,
+		},
+		{
+			name:        "PEM with PKCS#8 key",
+			certificate: pkcs8Key + certificate,
+		},
+		{
+			name:        "PEM with separate key",
+			certificate: certificate,
+			key:         pkcs8Key,
+		},
+		{
+			name:        "PEM without key",
+			certificate: certificate,
+			expectError: true,
+		},
+		{
+			name:        "PFX",
+			certificate: base64.StdEncoding.EncodeToString(pfx),
+			password:    "Pa55w0rd",
+		},
+		{
+			name:        "PFX with the wrong password",
+			certificate: base64.StdEncoding.EncodeToString(pfx),
+			password:    "wrong",
+			expectError: true,
+		},
+		{
+			name:        "neither PEM nor base64",
+			certificate: "not a certificate",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := servicePrincipalClientCertificateAuth{
+				clientCert:         tc.certificate,
+				clientCertKey:      tc.key,
+				clientCertPassword: tc.password,
+			}
+
+			data, err := auth.certificateData()
+			if err == nil {
+				_, _, err = decodeCertificate(data, []byte(auth.clientCertKey), auth.clientCertPassword)
+			}
+
+			if tc.expectError && err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeCertificate(t *testing.T) {
+	certificate, pkcs1Key, pkcs8Key := testCertificate(t)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %+v", err)
+	}
+	ecPkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("Error encoding key: %+v", err)
+	}
+	ecdsaKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPkcs8}))
+
+	// the content of encrypted keys is irrelevant, as they're rejected based on their type and headers
+	legacyEncryptedKey := string(pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY",
+		Headers: map[string]string{
+			"Proc-Type": "4,ENCRYPTED",
+			"DEK-Info":  "AES-256-CBC,00000000000000000000000000000000",
+		},
+		Bytes: []byte("encrypted"),
+	}))
+	pkcs8EncryptedKey := string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")}))
+
+	cases := []struct {
+		name          string
+		data          string
+		key           string
+		expectedError string
+	}{
+		{
+			name: "PKCS#1 key after the certificate",
+			data: certificate + pkcs1Key,
+		},
+		{
+			name: "PKCS#8 key before the certificate",
+			data: pkcs8Key + certificate,
+		},
+		{
+			name: "PKCS#1 key given separately",
+			data: certificate,
+			key:  pkcs1Key,
+		},
+		{
+			name: "PKCS#8 key given separately",
+			data: certificate,
+			key:  pkcs8Key,
+		},
+		{
+			name:          "encrypted PKCS#1 key",
+			data:          certificate + legacyEncryptedKey,
+			expectedError: "Encrypted PEM private keys are not supported",
+		},
+		{
+			name:          "encrypted PKCS#8 key",
+			data:          certificate,
+			key:           pkcs8EncryptedKey,
+			expectedError: "Encrypted PEM private keys are not supported",
+		},
+		{
+			name:          "PKCS#8 key which isn't RSA",
+			data:          certificate + ecdsaKey,
+			expectedError: "must contain an RSA private key",
+		},
+		{
+			name:          "malformed PKCS#8 key",
+			data:          certificate + string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("malformed")})),
+			expectedError: "Error parsing PEM private key",
+		},
+		{
+			name:          "key without a certificate",
+			data:          pkcs8Key,
+			expectedError: "No certificate was found",
+		},
+		{
+			name:          "certificate without a key",
+			data:          certificate,
+			expectedError: "No private key was found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert, key, err := decodeCertificate([]byte(tc.data), []byte(tc.key), "")
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("Expected an error containing %q, got: %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			if publicKey, ok := cert.PublicKey.(*rsa.PublicKey); !ok || publicKey.N.Cmp(key.PublicKey.N) != 0 {
+				t.Fatalf("Expected the private key to belong to the certificate")
+			}
+		})
+	}
+}
+
+func TestClientCertificateAuth_validate(t *testing.T) {
+	builder := Builder{
+		ClientID:               "00000000-0000-0000-0000-000000000000",
+		TenantID:               "11111111-1111-1111-1111-111111111111",
+		SupportsClientCertAuth: true,
+		ClientCert:             "content",
+	}
+
+	if _, err := builder.Build(); err != nil {
+		t.Fatalf("Expected the Client Certificate to be sufficient without a path: %+v", err)
+	}
+
+	builder.ClientCertPath = "testdata/certificate.pfx"
+	if _, err := builder.Build(); err == nil {
+		t.Fatalf("Expected an error when both the Client Certificate and its path are configured")
+	}
+}
@@ -9,18 +9,16 @@ import (
 )
 
 type servicePrincipalClientSecretAuth struct {
-	clientId       string
-	clientSecret   string
-	subscriptionId string
-	tenantId       string
+	clientId     string
+	clientSecret string
+	tenantId     string
 }
 
 func (a servicePrincipalClientSecretAuth) build(b Builder) (authMethod, error) {
 	method := servicePrincipalClientSecretAuth{
-		clientId:       b.ClientID,
-		clientSecret:   b.ClientSecret,
-		subscriptionId: b.SubscriptionID,
-		tenantId:       b.TenantID,
+		clientId:     b.ClientID,
+		clientSecret: b.ClientSecret,
+		tenantId:     b.TenantID,
 	}
 	return method, nil
 }
@@ -53,9 +51,6 @@ func (a servicePrincipalClientSecretAuth) validate() error {
 
 	fmtErrorMessage := "A %s must be configured when authenticating as a Service Principal using a Client Secret."
 
-	if a.subscriptionId == "" {
-		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Subscription ID"))
-	}
 	if a.clientId == "" {
 		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Client ID"))
 	}
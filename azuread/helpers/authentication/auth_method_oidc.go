@@ -0,0 +1,122 @@
+package authentication
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-multierror"
+)
+
+// oidcAuth authenticates as a Service Principal using a federated identity credential, by exchanging a JWT issued
+// by a trusted identity provider (such as GitHub Actions, GitLab or Kubernetes) for a token
+type oidcAuth struct {
+	clientId          string
+	tenantId          string
+	oidcToken         string
+	oidcTokenFilePath string
+}
+
+func (a oidcAuth) build(b Builder) (authMethod, error) {
+	method := oidcAuth{
+		clientId:          b.ClientID,
+		tenantId:          b.TenantID,
+		oidcToken:         b.OIDCToken,
+		oidcTokenFilePath: b.OIDCTokenFilePath,
+	}
+	return method, nil
+}
+
+func (a oidcAuth) isApplicable(b Builder) bool {
+	return b.SupportsOIDCAuth && (b.OIDCToken != "" || b.OIDCTokenFilePath != "")
+}
+
+func (a oidcAuth) name() string {
+	return "Service Principal / OIDC Token"
+}
+
+func (a oidcAuth) getAuthorizationToken(oauthConfig *adal.OAuthConfig, endpoint string) (*autorest.BearerAuthorizer, error) {
+	secret := &oidcAssertionSecret{
+		token:         a.oidcToken,
+		tokenFilePath: a.oidcTokenFilePath,
+	}
+
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, a.clientId, endpoint, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = spt.Refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	auth := autorest.NewBearerAuthorizer(spt)
+	return auth, nil
+}
+
+func (a oidcAuth) populateConfig(c *Config) error {
+	c.AuthenticatedAsAServicePrincipal = true
+	return nil
+}
+
+func (a oidcAuth) validate() error {
+	var err *multierror.Error
+
+	fmtErrorMessage := "A %s must be configured when authenticating as a Service Principal using an OIDC Token."
+
+	if a.clientId == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Client ID"))
+	}
+	if a.tenantId == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Tenant ID"))
+	}
+	if a.oidcToken == "" && a.oidcTokenFilePath != "" {
+		if _, fileErr := os.Stat(a.oidcTokenFilePath); fileErr != nil {
+			err = multierror.Append(err, fmt.Errorf("Error locating the OIDC Token File specified at %q: %s", a.oidcTokenFilePath, fileErr))
+		}
+	}
+
+	return err.ErrorOrNil()
+}
+
+// oidcAssertionSecret presents the OIDC token as a client assertion. When read from a file the token is read again
+// each time it's exchanged, since identity providers such as Kubernetes rotate the file before the token expires.
+type oidcAssertionSecret struct {
+	token         string
+	tokenFilePath string
+}
+
+func (s *oidcAssertionSecret) assertion() (string, error) {
+	if s.token != "" {
+		return s.token, nil
+	}
+
+	contents, err := ioutil.ReadFile(s.tokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Error reading the OIDC Token File %q: %+v", s.tokenFilePath, err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", fmt.Errorf("The OIDC Token File %q is empty", s.tokenFilePath)
+	}
+
+	return token, nil
+}
+
+// SetAuthenticationValues populates the form submitted to the token endpoint with the client assertion
+func (s *oidcAssertionSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	token, err := s.assertion()
+	if err != nil {
+		return err
+	}
+
+	v.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	v.Set("client_assertion", token)
+	return nil
+}
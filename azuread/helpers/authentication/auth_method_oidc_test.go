@@ -0,0 +1,162 @@
+package authentication
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// testTokenEndpoint returns a server which issues a token in exchange for the expected client assertion
+func testTokenEndpoint(t *testing.T, expectedAssertion string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Error parsing the token request: %+v", err)
+		}
+
+		if r.Form.Get("grant_type") != "client_credentials" {
+			t.Errorf("Expected the client credentials grant, got %q", r.Form.Get("grant_type"))
+		}
+		if r.Form.Get("client_assertion_type") != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+			t.Errorf("Expected a JWT client assertion, got %q", r.Form.Get("client_assertion_type"))
+		}
+		if r.Form.Get("client_assertion") != expectedAssertion {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{ // nolint: errcheck
+			"access_token": "graph-token",
+			"expires_in":   "3600",
+			"expires_on":   "4102444800",
+			"not_before":   "0",
+			"resource":     r.Form.Get("resource"),
+			"token_type":   "Bearer",
+		})
+	}))
+}
+
+func TestOIDCAuth_token(t *testing.T) {
+	server := testTokenEndpoint(t, "header.payload.signature")
+	defer server.Close()
+
+	builder := Builder{
+		ClientID:         "00000000-0000-0000-0000-000000000000",
+		TenantID:         "11111111-1111-1111-1111-111111111111",
+		SupportsOIDCAuth: true,
+		OIDCToken:        "header.payload.signature",
+	}
+
+	config, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Error building config: %+v", err)
+	}
+
+	oauthConfig, err := adal.NewOAuthConfig(server.URL, builder.TenantID)
+	if err != nil {
+		t.Fatalf("Error building OAuth config: %+v", err)
+	}
+
+	if _, err := config.GetAuthorizationToken(oauthConfig, "https://graph.windows.net/"); err != nil {
+		t.Fatalf("Expected the OIDC token to be exchanged: %+v", err)
+	}
+}
+
+func TestOIDCAuth_tokenFile(t *testing.T) {
+	server := testTokenEndpoint(t, "header.payload.signature")
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "oidc")
+	if err != nil {
+		t.Fatalf("Error creating directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(path, []byte("header.payload.signature\n"), 0600); err != nil {
+		t.Fatalf("Error writing token: %+v", err)
+	}
+
+	builder := Builder{
+		ClientID:          "00000000-0000-0000-0000-000000000000",
+		TenantID:          "11111111-1111-1111-1111-111111111111",
+		SupportsOIDCAuth:  true,
+		OIDCTokenFilePath: path,
+	}
+
+	config, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Error building config: %+v", err)
+	}
+
+	oauthConfig, err := adal.NewOAuthConfig(server.URL, builder.TenantID)
+	if err != nil {
+		t.Fatalf("Error building OAuth config: %+v", err)
+	}
+
+	if _, err := config.GetAuthorizationToken(oauthConfig, "https://graph.windows.net/"); err != nil {
+		t.Fatalf("Expected the OIDC token read from the file to be exchanged: %+v", err)
+	}
+}
+
+func TestOIDCAuth_validate(t *testing.T) {
+	builder := Builder{
+		SupportsOIDCAuth:  true,
+		OIDCTokenFilePath: "/does/not/exist",
+	}
+
+	if _, err := builder.Build(); err == nil {
+		t.Fatalf("Expected an error when the Client ID, Tenant ID and token file are missing")
+	}
+}
+
+func TestOIDCAuth_tokensAreShared(t *testing.T) {
+	requests := 0
+	server := testTokenEndpoint(t, "shared.payload.signature")
+	defer server.Close()
+	counter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer counter.Close()
+
+	token := func(tenantId string) {
+		builder := Builder{
+			ClientID:         "00000000-0000-0000-0000-000000000000",
+			TenantID:         tenantId,
+			SupportsOIDCAuth: true,
+			OIDCToken:        "shared.payload.signature",
+		}
+
+		config, err := builder.Build()
+		if err != nil {
+			t.Fatalf("Error building config: %+v", err)
+		}
+
+		oauthConfig, err := adal.NewOAuthConfig(counter.URL, tenantId)
+		if err != nil {
+			t.Fatalf("Error building OAuth config: %+v", err)
+		}
+
+		if _, err := config.GetAuthorizationToken(oauthConfig, "https://graph.windows.net/"); err != nil {
+			t.Fatalf("Error obtaining token: %+v", err)
+		}
+	}
+
+	token("11111111-1111-1111-1111-111111111111")
+	token("11111111-1111-1111-1111-111111111111")
+	if requests != 1 {
+		t.Fatalf("Expected instances with the same client and tenant to share a token, but %d were requested", requests)
+	}
+
+	token("22222222-2222-2222-2222-222222222222")
+	if requests != 2 {
+		t.Fatalf("Expected a token to be requested for another tenant, but %d were requested", requests)
+	}
+}
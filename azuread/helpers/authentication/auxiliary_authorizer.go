@@ -0,0 +1,53 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const auxiliaryAuthorizationHeader = "x-ms-authorization-auxiliary"
+
+// auxiliaryTenantsAuthorizer authorizes requests with the token for the primary tenant, and passes the tokens for the
+// auxiliary tenants alongside it, so that a multi-tenant application can act on objects in those tenants
+type auxiliaryTenantsAuthorizer struct {
+	primary   autorest.Authorizer
+	auxiliary []autorest.Authorizer
+}
+
+// NewAuxiliaryTenantsAuthorizer returns an Authorizer which adds the tokens of the auxiliary authorizers to each
+// request in the x-ms-authorization-auxiliary header, or the primary authorizer when there are none
+func NewAuxiliaryTenantsAuthorizer(primary autorest.Authorizer, auxiliary []autorest.Authorizer) autorest.Authorizer {
+	if len(auxiliary) == 0 {
+		return primary
+	}
+
+	return auxiliaryTenantsAuthorizer{
+		primary:   primary,
+		auxiliary: auxiliary,
+	}
+}
+
+func (a auxiliaryTenantsAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := autorest.DecoratePreparer(p, a.primary.WithAuthorization()).Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			tokens := make([]string, 0, len(a.auxiliary))
+			for _, auxiliary := range a.auxiliary {
+				// the authorizers only expose their token by setting the Authorization header, which also refreshes it
+				t, err := autorest.Prepare((&http.Request{URL: r.URL, Header: http.Header{}}).WithContext(r.Context()), auxiliary.WithAuthorization())
+				if err != nil {
+					return r, err
+				}
+				tokens = append(tokens, t.Header.Get("Authorization"))
+			}
+
+			return autorest.Prepare(r, autorest.WithHeader(auxiliaryAuthorizationHeader, strings.Join(tokens, ", ")))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package authentication
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func testBearerAuthorizer(token string) autorest.Authorizer {
+	return autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{
+		"Authorization": "Bearer " + token,
+	})
+}
+
+func TestAuxiliaryTenantsAuthorizer(t *testing.T) {
+	authorizer := NewAuxiliaryTenantsAuthorizer(testBearerAuthorizer("primary"), []autorest.Authorizer{
+		testBearerAuthorizer("auxiliary1"),
+		testBearerAuthorizer("auxiliary2"),
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "https://graph.windows.net/tenant/users", nil)
+	if err != nil {
+		t.Fatalf("Error building request: %+v", err)
+	}
+
+	req, err = autorest.Prepare(req, authorizer.WithAuthorization())
+	if err != nil {
+		t.Fatalf("Error preparing request: %+v", err)
+	}
+
+	if v := req.Header.Get("Authorization"); v != "Bearer primary" {
+		t.Fatalf("Expected the primary token in the Authorization header, got %q", v)
+	}
+	if v := req.Header.Get("x-ms-authorization-auxiliary"); v != "Bearer auxiliary1, Bearer auxiliary2" {
+		t.Fatalf("Expected the auxiliary tokens in the x-ms-authorization-auxiliary header, got %q", v)
+	}
+}
+
+func TestAuxiliaryTenantsAuthorizer_none(t *testing.T) {
+	primary := testBearerAuthorizer("primary")
+	if authorizer := NewAuxiliaryTenantsAuthorizer(primary, nil); authorizer != primary {
+		t.Fatalf("Expected the primary authorizer when there are no auxiliary tenants")
+	}
+}
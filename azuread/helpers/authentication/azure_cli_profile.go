@@ -0,0 +1,95 @@
+package authentication
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+)
+
+// azureCLIClientId is the Client ID of the Azure CLI itself, which is used when the CLI doesn't cache its tokens in
+// a form which can be read
+const azureCLIClientId = "04b07795-8ddb-461a-bbee-02f9e1bf7b46"
+
+type azureCLIProfile struct {
+	profile cli.Profile
+
+	clientId    string
+	environment string
+	tenantId    string
+}
+
+// populateFields determines the tenant and environment from the Azure CLI. The tenant is taken from the active account
+// when one isn't specified - which, for a tenant without any Subscriptions, is the tenant itself when logged in using
+// `az login --allow-no-subscriptions`.
+func (a *azureCLIProfile) populateFields() error {
+	account, err := a.findAccount()
+	if err != nil {
+		return err
+	}
+
+	if a.tenantId == "" {
+		a.tenantId = account.TenantID
+	}
+
+	// always pull the environment from the Azure CLI, since the Access Token's associated with it
+	a.environment = normalizeEnvironmentName(account.EnvironmentName)
+	return nil
+}
+
+// findAccount returns the account the CLI should use: the default account for the tenant when one is specified,
+// otherwise the default account
+func (a azureCLIProfile) findAccount() (*cli.Subscription, error) {
+	var account *cli.Subscription
+	for i, subscription := range a.profile.Subscriptions {
+		if a.tenantId != "" && !strings.EqualFold(subscription.TenantID, a.tenantId) {
+			continue
+		}
+
+		if subscription.IsDefault {
+			return &a.profile.Subscriptions[i], nil
+		}
+		if account == nil {
+			account = &a.profile.Subscriptions[i]
+		}
+	}
+
+	if account == nil {
+		if a.tenantId != "" {
+			return nil, fmt.Errorf("Tenant %q was not found in your Azure CLI credentials. Please log in to it using `az login --tenant %s --allow-no-subscriptions`.", a.tenantId, a.tenantId)
+		}
+		return nil, fmt.Errorf("No account was found in your Azure CLI credentials.")
+	}
+
+	return account, nil
+}
+
+func (a *azureCLIProfile) populateClientId() error {
+	if a.clientId != "" {
+		return nil
+	}
+
+	// we can now pull out the ClientID from an Access Token cached for the tenant
+	tokensPath, err := cli.AccessTokensPath()
+	if err != nil {
+		return fmt.Errorf("Error loading the Tokens Path from the Azure CLI: %+v", err)
+	}
+
+	a.clientId = azureCLIClientId
+
+	tokens, err := cli.LoadTokens(tokensPath)
+	if err != nil {
+		log.Printf("[DEBUG] No Access Tokens were found in the Azure CLI, using the Client ID of the Azure CLI: %+v", err)
+		return nil
+	}
+
+	for _, token := range tokens {
+		if strings.HasSuffix(token.Authority, a.tenantId) && token.ClientID != "" {
+			a.clientId = token.ClientID
+			break
+		}
+	}
+
+	return nil
+}
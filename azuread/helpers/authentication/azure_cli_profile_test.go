@@ -0,0 +1,91 @@
+package authentication
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+)
+
+func testAzureCLIProfile() cli.Profile {
+	return cli.Profile{
+		Subscriptions: []cli.Subscription{
+			{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				TenantID:        "11111111-1111-1111-1111-111111111111",
+				EnvironmentName: "AzureCloud",
+			},
+			{
+				// when logged in with `--allow-no-subscriptions` the tenant itself is listed as an account
+				ID:              "22222222-2222-2222-2222-222222222222",
+				TenantID:        "22222222-2222-2222-2222-222222222222",
+				EnvironmentName: "AzureChinaCloud",
+				IsDefault:       true,
+			},
+			{
+				ID:              "00000000-0000-0000-0000-000000000003",
+				TenantID:        "33333333-3333-3333-3333-333333333333",
+				EnvironmentName: "AzureUSGovernment",
+			},
+		},
+	}
+}
+
+func TestAzureCLIProfile_populateFields(t *testing.T) {
+	cases := []struct {
+		name                string
+		tenantId            string
+		expectedTenantId    string
+		expectedEnvironment string
+		expectError         bool
+	}{
+		{
+			name:                "active account",
+			expectedTenantId:    "22222222-2222-2222-2222-222222222222",
+			expectedEnvironment: "china",
+		},
+		{
+			name:                "tenant with a subscription",
+			tenantId:            "11111111-1111-1111-1111-111111111111",
+			expectedTenantId:    "11111111-1111-1111-1111-111111111111",
+			expectedEnvironment: "public",
+		},
+		{
+			name:                "tenant",
+			tenantId:            "33333333-3333-3333-3333-333333333333",
+			expectedTenantId:    "33333333-3333-3333-3333-333333333333",
+			expectedEnvironment: "usgovernment",
+		},
+		{
+			name:        "unknown tenant",
+			tenantId:    "99999999-9999-9999-9999-999999999999",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := azureCLIProfile{
+				profile:  testAzureCLIProfile(),
+				tenantId: tc.tenantId,
+			}
+
+			err := profile.populateFields()
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if profile.tenantId != tc.expectedTenantId {
+				t.Fatalf("Expected the Tenant ID to be %q but got %q", tc.expectedTenantId, profile.tenantId)
+			}
+			if profile.environment != tc.expectedEnvironment {
+				t.Fatalf("Expected the Environment to be %q but got %q", tc.expectedEnvironment, profile.environment)
+			}
+		})
+	}
+}
@@ -7,16 +7,18 @@ import (
 
 // Builder supports all of the possible Authentication values and feature toggles
 // required to build a working Config for Authentication purposes.
+//
+// Unlike go-azure-helpers, which this is derived from, only a Tenant ID is required: Azure Active Directory
+// is independent of any Subscription, and tenants used solely for identity often have none.
 type Builder struct {
 	// Core
-	ClientID       string
-	SubscriptionID string
-	TenantID       string
-	Environment    string
+	ClientID    string
+	TenantID    string
+	Environment string
 
-	// The custom Resource Manager Endpoint which should be used
-	// only applicable for Azure Stack at this time.
-	CustomResourceManagerEndpoint string
+	// AuxiliaryTenantIDs are other tenants the credentials (typically of a multi-tenant application) must be able to
+	// authenticate to, in addition to the primary tenant
+	AuxiliaryTenantIDs []string
 
 	// Azure CLI Tokens Auth
 	SupportsAzureCliToken bool
@@ -25,25 +27,32 @@ type Builder struct {
 	SupportsManagedServiceIdentity bool
 	MsiEndpoint                    string
 
-	// Service Principal (Client Cert) Auth
+	// Service Principal (Client Cert) Auth, using either the path to a certificate or the certificate itself, which
+	// can be a base64 encoded PKCS#12 (PFX) file, or PEM encoded with the private key optionally specified separately
 	SupportsClientCertAuth bool
 	ClientCertPath         string
+	ClientCert             string
+	ClientCertKey          string
 	ClientCertPassword     string
 
 	// Service Principal (Client Secret) Auth
 	SupportsClientSecretAuth bool
 	ClientSecret             string
+
+	// Service Principal (OIDC) Auth, where the token is used in preference to the file
+	SupportsOIDCAuth  bool
+	OIDCToken         string
+	OIDCTokenFilePath string
 }
 
 // Build takes the configuration from the Builder and builds up a validated Config
 // for authenticating with Azure
 func (b Builder) Build() (*Config, error) {
 	config := Config{
-		ClientID:                      b.ClientID,
-		SubscriptionID:                b.SubscriptionID,
-		TenantID:                      b.TenantID,
-		Environment:                   b.Environment,
-		CustomResourceManagerEndpoint: b.CustomResourceManagerEndpoint,
+		ClientID:           b.ClientID,
+		TenantID:           b.TenantID,
+		AuxiliaryTenantIDs: b.AuxiliaryTenantIDs,
+		Environment:        b.Environment,
 	}
 
 	// NOTE: the ordering here is important
@@ -51,6 +60,7 @@ func (b Builder) Build() (*Config, error) {
 	supportedAuthenticationMethods := []authMethod{
 		servicePrincipalClientCertificateAuth{},
 		servicePrincipalClientSecretAuth{},
+		oidcAuth{},
 		managedServiceIdentityAuth{},
 		azureCliTokenAuth{},
 	}
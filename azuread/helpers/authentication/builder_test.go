@@ -0,0 +1,37 @@
+package authentication
+
+import (
+	"testing"
+)
+
+func TestBuilder_clientSecretWithoutSubscription(t *testing.T) {
+	builder := Builder{
+		ClientID:                 "00000000-0000-0000-0000-000000000000",
+		ClientSecret:             "secret",
+		TenantID:                 "11111111-1111-1111-1111-111111111111",
+		Environment:              "public",
+		SupportsClientSecretAuth: true,
+	}
+
+	config, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Expected no error when a Subscription ID isn't specified but got: %+v", err)
+	}
+
+	if !config.AuthenticatedAsAServicePrincipal {
+		t.Fatalf("Expected to be authenticated as a Service Principal")
+	}
+}
+
+func TestBuilder_clientSecretRequiresTenant(t *testing.T) {
+	builder := Builder{
+		ClientID:                 "00000000-0000-0000-0000-000000000000",
+		ClientSecret:             "secret",
+		Environment:              "public",
+		SupportsClientSecretAuth: true,
+	}
+
+	if _, err := builder.Build(); err == nil {
+		t.Fatalf("Expected an error when a Tenant ID isn't specified")
+	}
+}
@@ -1,29 +1,28 @@
 package authentication
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 )
 
-// Config is the configuration structure used to instantiate a
-// new Azure management client.
+// Config is the configuration structure used to instantiate the Graph clients
 type Config struct {
 	ClientID                         string
-	SubscriptionID                   string
 	TenantID                         string
+	AuxiliaryTenantIDs               []string
 	Environment                      string
 	AuthenticatedAsAServicePrincipal bool
 
-	// A Custom Resource Manager Endpoint
-	// at this time this should only be applicable for Azure Stack.
-	CustomResourceManagerEndpoint string
-
 	authMethod authMethod
 }
 
-// GetAuthorizationToken returns an authorization token for the authentication method defined in the Config
+// GetAuthorizationToken returns an authorization token for the authentication method defined in the Config, which is
+// shared with other instances using the same client, tenant and endpoint
 func (c Config) GetAuthorizationToken(oauthConfig *adal.OAuthConfig, endpoint string) (*autorest.BearerAuthorizer, error) {
-	return c.authMethod.getAuthorizationToken(oauthConfig, endpoint)
+	return cachedAuthorizationToken(c.authMethod, c.ClientID, oauthConfig, endpoint)
 }
 
 func (c Config) validate() (*Config, error) {
@@ -32,5 +31,11 @@ func (c Config) validate() (*Config, error) {
 		return nil, err
 	}
 
+	for _, tenantId := range c.AuxiliaryTenantIDs {
+		if strings.EqualFold(tenantId, c.TenantID) {
+			return nil, fmt.Errorf("The Tenant %q is both the primary tenant and an auxiliary tenant", tenantId)
+		}
+	}
+
 	return &c, nil
 }
@@ -0,0 +1,57 @@
+package authentication
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// Tokens are cached for the lifetime of the process, keyed by the tenant (through the token endpoint), client and
+// resource, so that provider instances which authenticate as the same client - such as aliased providers in one
+// configuration - share a token rather than each acquiring their own. Tokens are issued per tenant, so instances
+// for different tenants share the client but not the tokens.
+// The authorizers are safe to share, since the underlying tokens refresh themselves under a lock.
+
+type tokenCacheEntry struct {
+	sync.Mutex
+	authorizer *autorest.BearerAuthorizer
+}
+
+var tokenCache = struct {
+	sync.Mutex
+	entries map[string]*tokenCacheEntry
+}{
+	entries: make(map[string]*tokenCacheEntry),
+}
+
+// cachedAuthorizationToken returns the cached token for the tenant, client and resource, acquiring it when there
+// isn't one. Concurrent requests for the same token wait for a single acquisition rather than each making their own.
+func cachedAuthorizationToken(method authMethod, clientId string, oauthConfig *adal.OAuthConfig, endpoint string) (*autorest.BearerAuthorizer, error) {
+	key := strings.Join([]string{oauthConfig.TokenEndpoint.String(), clientId, endpoint}, "\n")
+
+	tokenCache.Lock()
+	entry, ok := tokenCache.entries[key]
+	if !ok {
+		entry = &tokenCacheEntry{}
+		tokenCache.entries[key] = entry
+	}
+	tokenCache.Unlock()
+
+	entry.Lock()
+	defer entry.Unlock()
+
+	if entry.authorizer != nil {
+		return entry.authorizer, nil
+	}
+
+	// errors aren't cached, so that a later attempt can succeed
+	authorizer, err := method.getAuthorizationToken(oauthConfig, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.authorizer = authorizer
+	return authorizer, nil
+}
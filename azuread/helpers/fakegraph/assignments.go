@@ -0,0 +1,205 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const defaultAccessAppRoleId = "00000000-0000-0000-0000-000000000000"
+
+// the Object IDs of App Role Assignments and OAuth2 Permission Grants are not UUIDs
+func newOpaqueId() (string, error) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(id, "-", "", -1), nil
+}
+
+func (s *Server) handleAppRoleAssignments(w http.ResponseWriter, r *http.Request, principal *object, segments []string, body map[string]interface{}) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		values := make([]interface{}, 0)
+		for _, a := range s.appRoleAssignments {
+			if strings.EqualFold(a["principalId"].(string), principal.id()) {
+				values = append(values, a)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"value": values,
+		})
+		return
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		resourceId, _ := body["resourceId"].(string)
+		roleId, _ := body["id"].(string)
+
+		resource := s.find(collectionServicePrincipals, resourceId)
+		if resource == nil {
+			writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", resourceId))
+			return
+		}
+
+		// the zero UUID assigns default access, which isn't one of the App Roles of the resource
+		if roleId != defaultAccessAppRoleId && !hasEntitlement(resource.data["appRoles"], roleId) {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Permission being assigned was not found on application")
+			return
+		}
+
+		for _, a := range s.appRoleAssignments {
+			if strings.EqualFold(a["principalId"].(string), principal.id()) && strings.EqualFold(a["resourceId"].(string), resourceId) && strings.EqualFold(a["id"].(string), roleId) {
+				writeError(w, http.StatusBadRequest, "Request_BadRequest", "Permission being assigned already exists on the object")
+				return
+			}
+		}
+
+		id, err := newOpaqueId()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Fake_InternalError", err.Error())
+			return
+		}
+
+		assignment := map[string]interface{}{
+			"objectId":             id,
+			"objectType":           "AppRoleAssignment",
+			"odata.type":           "Microsoft.DirectoryServices.AppRoleAssignment",
+			"creationTimestamp":    time.Now().UTC().Format(time.RFC3339),
+			"id":                   roleId,
+			"principalDisplayName": principal.data["displayName"],
+			"principalId":          principal.id(),
+			"principalType":        principal.data["objectType"],
+			"resourceDisplayName":  resource.data["displayName"],
+			"resourceId":           resource.id(),
+		}
+		s.appRoleAssignments[id] = assignment
+
+		writeJSON(w, http.StatusCreated, assignment)
+		return
+
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		a, ok := s.appRoleAssignments[segments[0]]
+		if !ok || !strings.EqualFold(a["principalId"].(string), principal.id()) {
+			writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", segments[0]))
+			return
+		}
+
+		delete(s.appRoleAssignments, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeError(w, http.StatusNotImplemented, "Fake_NotImplemented", fmt.Sprintf("%s %s is not implemented by the fake Graph API", r.Method, r.URL.Path))
+}
+
+func (s *Server) handleOAuth2PermissionGrants(w http.ResponseWriter, r *http.Request, segments []string, body map[string]interface{}) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		f, err := parseFilter(r.URL.Query().Get("$filter"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", err.Error())
+			return
+		}
+
+		values := make([]interface{}, 0)
+		for _, g := range s.oauth2PermissionGrants {
+			if f.matches(g) {
+				values = append(values, g)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"value": values,
+		})
+		return
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		clientId, _ := body["clientId"].(string)
+		resourceId, _ := body["resourceId"].(string)
+
+		for _, id := range []string{clientId, resourceId} {
+			if s.find(collectionServicePrincipals, id) == nil {
+				writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id))
+				return
+			}
+		}
+
+		id, err := newOpaqueId()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Fake_InternalError", err.Error())
+			return
+		}
+
+		grant := map[string]interface{}{
+			"objectId":    id,
+			"odata.type":  "Microsoft.DirectoryServices.OAuth2PermissionGrant",
+			"principalId": nil,
+		}
+		for k, v := range body {
+			grant[k] = v
+		}
+		s.oauth2PermissionGrants[id] = grant
+
+		writeJSON(w, http.StatusCreated, grant)
+		return
+
+	case len(segments) == 1:
+		grant, ok := s.oauth2PermissionGrants[segments[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", segments[0]))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, grant)
+			return
+		case http.MethodPatch:
+			for k, v := range body {
+				grant[k] = v
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodDelete:
+			delete(s.oauth2PermissionGrants, segments[0])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotImplemented, "Fake_NotImplemented", fmt.Sprintf("%s %s is not implemented by the fake Graph API", r.Method, r.URL.Path))
+}
+
+// removeReferencesTo removes the assignments and grants which reference a deleted object
+func (s *Server) removeReferencesTo(id string) {
+	for k, a := range s.appRoleAssignments {
+		if strings.EqualFold(a["principalId"].(string), id) || strings.EqualFold(a["resourceId"].(string), id) {
+			delete(s.appRoleAssignments, k)
+		}
+	}
+
+	for k, g := range s.oauth2PermissionGrants {
+		for _, property := range []string{"clientId", "resourceId", "principalId"} {
+			if v, ok := g[property].(string); ok && strings.EqualFold(v, id) {
+				delete(s.oauth2PermissionGrants, k)
+				break
+			}
+		}
+	}
+}
+
+func hasEntitlement(entitlements interface{}, id string) bool {
+	list, _ := entitlements.([]interface{})
+	for _, e := range list {
+		if m, ok := e.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprintf("%v", m["id"]), id) {
+			return true
+		}
+	}
+
+	return false
+}
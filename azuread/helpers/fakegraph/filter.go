@@ -0,0 +1,112 @@
+package fakegraph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filter is a parsed OData filter, supporting the subset used by the provider: `eq` and `startswith()` clauses on
+// string properties, combined using either `and` or `or`
+type filter struct {
+	any     bool
+	clauses []clause
+}
+
+type clause struct {
+	property string
+	value    string
+	prefix   bool
+}
+
+var (
+	eqClause         = regexp.MustCompile(`^(\w+)\s+eq\s+'((?:[^']|'')*)'$`)
+	startsWithClause = regexp.MustCompile(`^startswith\(\s*(\w+)\s*,\s*'((?:[^']|'')*)'\s*\)$`)
+	andOperator      = regexp.MustCompile(`\s+and\s+`)
+	orOperator       = regexp.MustCompile(`\s+or\s+`)
+)
+
+func parseFilter(input string) (*filter, error) {
+	f := &filter{}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return f, nil
+	}
+
+	terms := splitOutsideQuotes(input, andOperator)
+	if len(terms) == 1 {
+		terms = splitOutsideQuotes(input, orOperator)
+		f.any = true
+	} else if len(splitOutsideQuotes(input, orOperator)) > 1 {
+		return nil, fmt.Errorf("Unsupported filter %q: mixing `and` and `or` is not supported by the fake Graph API", input)
+	}
+
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+
+		if m := eqClause.FindStringSubmatch(term); m != nil {
+			f.clauses = append(f.clauses, clause{property: m[1], value: unescape(m[2])})
+			continue
+		}
+
+		if m := startsWithClause.FindStringSubmatch(term); m != nil {
+			f.clauses = append(f.clauses, clause{property: m[1], value: unescape(m[2]), prefix: true})
+			continue
+		}
+
+		return nil, fmt.Errorf("Unsupported filter clause %q", term)
+	}
+
+	return f, nil
+}
+
+func (f *filter) matches(data map[string]interface{}) bool {
+	if len(f.clauses) == 0 {
+		return true
+	}
+
+	for _, c := range f.clauses {
+		if c.matches(data) == f.any {
+			return f.any
+		}
+	}
+
+	return !f.any
+}
+
+func (c clause) matches(data map[string]interface{}) bool {
+	actual, ok := data[c.property].(string)
+	if !ok {
+		return false
+	}
+
+	// string comparisons are case insensitive
+	if c.prefix {
+		return strings.HasPrefix(strings.ToLower(actual), strings.ToLower(c.value))
+	}
+
+	return strings.EqualFold(actual, c.value)
+}
+
+func unescape(value string) string {
+	return strings.Replace(value, "''", "'", -1)
+}
+
+// splitOutsideQuotes splits the input on the operator, ignoring any occurrences within string literals
+func splitOutsideQuotes(input string, operator *regexp.Regexp) []string {
+	parts := make([]string, 0)
+
+	start := 0
+	for _, loc := range operator.FindAllStringIndex(input, -1) {
+		// a quote count which is odd means the operator is inside a literal
+		if strings.Count(input[:loc[0]], "'")%2 == 1 {
+			continue
+		}
+
+		parts = append(parts, input[start:loc[0]])
+		start = loc[1]
+	}
+
+	return append(parts, input[start:])
+}
@@ -0,0 +1,81 @@
+package fakegraph
+
+import (
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	object := map[string]interface{}{
+		"displayName":       "Test O'Brien",
+		"mailNickname":      "tobrien",
+		"userPrincipalName": "tobrien@example.com",
+	}
+
+	cases := []struct {
+		Filter  string
+		Matches bool
+		Error   bool
+	}{
+		{
+			Filter:  "",
+			Matches: true,
+		},
+		{
+			Filter:  "displayName eq 'Test O''Brien'",
+			Matches: true,
+		},
+		{
+			Filter:  "displayName eq 'test o''brien'",
+			Matches: true,
+		},
+		{
+			Filter:  "displayName eq 'Test'",
+			Matches: false,
+		},
+		{
+			Filter:  "startswith(displayName,'Test ')",
+			Matches: true,
+		},
+		{
+			Filter:  "startswith(displayName, 'Other')",
+			Matches: false,
+		},
+		{
+			Filter:  "mailNickname eq 'tobrien' and displayName eq 'Other'",
+			Matches: false,
+		},
+		{
+			Filter:  "mailNickname eq 'other' or userPrincipalName eq 'tobrien@example.com'",
+			Matches: true,
+		},
+		{
+			Filter:  "displayName eq 'Tom and Jerry' or mailNickname eq 'tobrien'",
+			Matches: true,
+		},
+		{
+			Filter: "mailNickname eq 'a' and displayName eq 'b' or displayName eq 'c'",
+			Error:  true,
+		},
+		{
+			Filter: "displayName ne 'Test'",
+			Error:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		f, err := parseFilter(tc.Filter)
+		if err != nil {
+			if !tc.Error {
+				t.Fatalf("Unexpected error parsing %q: %+v", tc.Filter, err)
+			}
+			continue
+		}
+		if tc.Error {
+			t.Fatalf("Expected an error parsing %q", tc.Filter)
+		}
+
+		if actual := f.matches(object); actual != tc.Matches {
+			t.Fatalf("Expected %q to match %t but got %t", tc.Filter, tc.Matches, actual)
+		}
+	}
+}
@@ -0,0 +1,613 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const (
+	collectionApplications      = "applications"
+	collectionGroups            = "groups"
+	collectionServicePrincipals = "servicePrincipals"
+	collectionUsers             = "users"
+)
+
+var objectTypes = map[string]string{
+	collectionApplications:      "Application",
+	collectionGroups:            "Group",
+	collectionServicePrincipals: "ServicePrincipal",
+	collectionUsers:             "User",
+}
+
+type object struct {
+	collection  string
+	data        map[string]interface{}
+	credentials map[string][]interface{}
+	created     int
+	visibleAt   time.Time
+
+	// stale is returned by reads until the most recent changes have replicated
+	stale      *snapshot
+	staleUntil time.Time
+}
+
+type snapshot struct {
+	data        map[string]interface{}
+	credentials map[string][]interface{}
+}
+
+func (o *object) id() string {
+	return o.data["objectId"].(string)
+}
+
+func (o *object) visible() bool {
+	return !time.Now().Before(o.visibleAt)
+}
+
+// view returns the properties of the object as seen by a read, which may not yet reflect recent changes
+func (o *object) view() map[string]interface{} {
+	if o.stale != nil && time.Now().Before(o.staleUntil) {
+		return o.stale.data
+	}
+
+	return o.data
+}
+
+func (o *object) viewCredentials(property string) []interface{} {
+	if o.stale != nil && time.Now().Before(o.staleUntil) {
+		return o.stale.credentials[property]
+	}
+
+	return o.credentials[property]
+}
+
+// beginChange records the current state of the object so that reads continue to return it until the change has
+// replicated, the original state is kept when an earlier change is still replicating
+func (s *Server) beginChange(obj *object) {
+	if s.replicationDelay == 0 {
+		return
+	}
+
+	if obj.stale == nil || !time.Now().Before(obj.staleUntil) {
+		stale := &snapshot{
+			data:        make(map[string]interface{}),
+			credentials: make(map[string][]interface{}),
+		}
+		for k, v := range obj.data {
+			stale.data[k] = v
+		}
+		for k, v := range obj.credentials {
+			stale.credentials[k] = v
+		}
+		obj.stale = stale
+	}
+
+	obj.staleUntil = time.Now().Add(s.replicationDelay)
+}
+
+func (o *object) string(key string) string {
+	v, _ := o.data[key].(string)
+	return v
+}
+
+func isCredentialsProperty(property string) bool {
+	return property == "passwordCredentials" || property == "keyCredentials"
+}
+
+func isLinkProperty(collection, property string) bool {
+	switch collection {
+	case collectionApplications, collectionServicePrincipals:
+		return property == "owners"
+	case collectionGroups:
+		return property == "members" || property == "owners"
+	}
+
+	return false
+}
+
+// find returns the visible object in the collection with the given Object ID, users can also be found by UPN
+func (s *Server) find(collection, id string) *object {
+	for _, obj := range s.objects {
+		if obj.collection != collection || !obj.visible() {
+			continue
+		}
+
+		if strings.EqualFold(obj.id(), id) {
+			return obj
+		}
+
+		if collection == collectionUsers && strings.EqualFold(obj.string("userPrincipalName"), id) {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findAny(id string) *object {
+	if obj, ok := s.objects[strings.ToLower(id)]; ok && obj.visible() {
+		return obj
+	}
+
+	return nil
+}
+
+// sorted returns the visible objects of a collection in the order they were created
+func (s *Server) sorted(collection string) []*object {
+	objects := make([]*object, 0)
+	for _, obj := range s.objects {
+		if obj.collection == collection && obj.visible() {
+			objects = append(objects, obj)
+		}
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].created < objects[j].created
+	})
+
+	return objects
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, collection string) {
+	query := r.URL.Query()
+
+	filter := query.Get("$filter")
+	offset := 0
+	if token := query.Get("$skiptoken"); token != "" {
+		page, ok := s.pages[token]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", fmt.Sprintf("Invalid skip token %q", token))
+			return
+		}
+		delete(s.pages, token)
+		filter = page.filter
+		offset = page.offset
+	}
+
+	f, err := parseFilter(filter)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", err.Error())
+		return
+	}
+
+	matches := make([]interface{}, 0)
+	for _, obj := range s.sorted(collection) {
+		if data := obj.view(); f.matches(data) {
+			matches = append(matches, data)
+		}
+	}
+
+	result := map[string]interface{}{}
+	if offset < len(matches) {
+		matches = matches[offset:]
+	} else {
+		matches = matches[:0]
+	}
+
+	if len(matches) > s.pageSize {
+		s.nextPage++
+		token := strconv.Itoa(s.nextPage)
+		s.pages[token] = listPage{
+			filter: filter,
+			offset: offset + s.pageSize,
+		}
+		matches = matches[:s.pageSize]
+		result["odata.nextLink"] = fmt.Sprintf("%s?$skiptoken=%s", collection, token)
+	}
+
+	result["value"] = matches
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) create(w http.ResponseWriter, collection string, body map[string]interface{}) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Fake_InternalError", err.Error())
+		return
+	}
+
+	data := make(map[string]interface{})
+	for k, v := range body {
+		data[k] = v
+	}
+	data["objectId"] = id
+	data["objectType"] = objectTypes[collection]
+	data["odata.type"] = "Microsoft.DirectoryServices." + objectTypes[collection]
+	data["deletionTimestamp"] = nil
+
+	credentials := map[string][]interface{}{
+		"passwordCredentials": make([]interface{}, 0),
+		"keyCredentials":      make([]interface{}, 0),
+	}
+	for property := range credentials {
+		if v, ok := data[property].([]interface{}); ok {
+			credentials[property] = prepareCredentials(v)
+		}
+		delete(data, property)
+	}
+
+	switch collection {
+	case collectionApplications:
+		if err := s.prepareApplication(data); err != nil {
+			writeError(w, http.StatusInternalServerError, "Fake_InternalError", err.Error())
+			return
+		}
+	case collectionServicePrincipals:
+		if status, err := s.prepareServicePrincipal(data); err != nil {
+			writeError(w, status, "Request_BadRequest", err.Error())
+			return
+		}
+	case collectionGroups:
+		setDefault(data, "description", nil)
+		setDefault(data, "mail", nil)
+	case collectionUsers:
+		if status, err := s.prepareUser(data); err != nil {
+			writeError(w, status, "Request_BadRequest", err.Error())
+			return
+		}
+	}
+
+	s.sequence++
+	s.objects[id] = &object{
+		collection:  collection,
+		data:        data,
+		credentials: credentials,
+		created:     s.sequence,
+		visibleAt:   time.Now().Add(s.replicationDelay),
+	}
+
+	// the API makes the caller an owner of the Applications it creates
+	if collection == collectionApplications {
+		s.links[id] = map[string][]string{
+			"owners": {s.CallerObjectID},
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, data)
+}
+
+// addCaller creates the Service Principal the requests are made as
+func (s *Server) addCaller() {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	appId, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+
+	s.CallerObjectID = id
+	s.sequence++
+	s.objects[id] = &object{
+		collection: collectionServicePrincipals,
+		data: map[string]interface{}{
+			"objectId":              id,
+			"objectType":            objectTypes[collectionServicePrincipals],
+			"odata.type":            "Microsoft.DirectoryServices." + objectTypes[collectionServicePrincipals],
+			"deletionTimestamp":     nil,
+			"accountEnabled":        true,
+			"appId":                 appId,
+			"appRoles":              make([]interface{}, 0),
+			"displayName":           "fakegraph",
+			"oauth2Permissions":     make([]interface{}, 0),
+			"servicePrincipalNames": []interface{}{appId},
+		},
+		credentials: map[string][]interface{}{
+			"passwordCredentials": make([]interface{}, 0),
+			"keyCredentials":      make([]interface{}, 0),
+		},
+		created: s.sequence,
+	}
+}
+
+func (s *Server) prepareApplication(data map[string]interface{}) error {
+	appId, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	data["appId"] = appId
+
+	setDefault(data, "appRoles", make([]interface{}, 0))
+	setDefault(data, "availableToOtherTenants", false)
+	setDefault(data, "groupMembershipClaims", nil)
+	setDefault(data, "homepage", nil)
+	setDefault(data, "identifierUris", make([]interface{}, 0))
+	setDefault(data, "oauth2AllowImplicitFlow", false)
+	setDefault(data, "publicClient", nil)
+	setDefault(data, "replyUrls", make([]interface{}, 0))
+	setDefault(data, "requiredResourceAccess", make([]interface{}, 0))
+
+	// the API creates a default permission when none are specified
+	if _, ok := data["oauth2Permissions"]; !ok {
+		permissionId, err := uuid.GenerateUUID()
+		if err != nil {
+			return err
+		}
+
+		name, _ := data["displayName"].(string)
+		data["oauth2Permissions"] = []interface{}{
+			map[string]interface{}{
+				"adminConsentDescription": fmt.Sprintf("Access %s", name),
+				"adminConsentDisplayName": fmt.Sprintf("Access %s", name),
+				"id":                      permissionId,
+				"isEnabled":               true,
+				"type":                    "User",
+				"userConsentDescription":  fmt.Sprintf("Allow the application to access %s on your behalf.", name),
+				"userConsentDisplayName":  fmt.Sprintf("Access %s", name),
+				"value":                   "user_impersonation",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) prepareServicePrincipal(data map[string]interface{}) (int, error) {
+	appId, _ := data["appId"].(string)
+
+	var app *object
+	for _, obj := range s.sorted(collectionApplications) {
+		if strings.EqualFold(obj.string("appId"), appId) {
+			app = obj
+		}
+	}
+	if app == nil {
+		return http.StatusBadRequest, fmt.Errorf("The appId '%s' of the service principal does not reference a valid application object.", appId)
+	}
+
+	for _, obj := range s.objects {
+		if obj.collection == collectionServicePrincipals && strings.EqualFold(obj.string("appId"), appId) {
+			return http.StatusBadRequest, fmt.Errorf("Another object with the same value for property servicePrincipalNames already exists.")
+		}
+	}
+
+	names := []interface{}{appId}
+	if uris, ok := app.data["identifierUris"].([]interface{}); ok {
+		names = append(names, uris...)
+	}
+
+	data["servicePrincipalNames"] = names
+	setDefault(data, "tags", make([]interface{}, 0))
+	syncServicePrincipal(data, app.data)
+
+	return 0, nil
+}
+
+// syncServicePrincipal copies the properties a Service Principal inherits from its Application
+func syncServicePrincipal(sp, app map[string]interface{}) {
+	for _, property := range []string{"appRoles", "displayName", "oauth2Permissions"} {
+		sp[property] = app[property]
+	}
+}
+
+func (s *Server) prepareUser(data map[string]interface{}) (int, error) {
+	upn, _ := data["userPrincipalName"].(string)
+	if upn == "" {
+		return http.StatusBadRequest, fmt.Errorf("Property userPrincipalName is required.")
+	}
+
+	for _, obj := range s.objects {
+		if obj.collection == collectionUsers && strings.EqualFold(obj.string("userPrincipalName"), upn) {
+			return http.StatusBadRequest, fmt.Errorf("Another object with the same value for property userPrincipalName already exists.")
+		}
+	}
+
+	// the password is write only
+	delete(data, "passwordProfile")
+
+	setDefault(data, "mail", nil)
+	setDefault(data, "userType", "Member")
+
+	return 0, nil
+}
+
+func (s *Server) update(w http.ResponseWriter, obj *object, body map[string]interface{}) {
+	if obj.collection == collectionUsers {
+		if upn, ok := body["userPrincipalName"].(string); ok {
+			for _, other := range s.objects {
+				if other != obj && other.collection == collectionUsers && strings.EqualFold(other.string("userPrincipalName"), upn) {
+					writeError(w, http.StatusBadRequest, "Request_BadRequest", "Another object with the same value for property userPrincipalName already exists.")
+					return
+				}
+			}
+		}
+		delete(body, "passwordProfile")
+	}
+
+	if obj.collection == collectionApplications {
+		// enabled entitlements must be disabled before they can be removed
+		for _, property := range []string{"appRoles", "oauth2Permissions"} {
+			if desired, ok := body[property]; ok {
+				if id := removedEnabledEntitlement(obj.data[property], desired); id != "" {
+					writeError(w, http.StatusBadRequest, "CannotDeleteOrUpdateEnabledEntitlement", fmt.Sprintf("Permission (scope or role) cannot be deleted or updated unless disabled first. Id: %s", id))
+					return
+				}
+			}
+		}
+	}
+
+	s.beginChange(obj)
+	for k, v := range body {
+		if isCredentialsProperty(k) {
+			if values, ok := v.([]interface{}); ok {
+				obj.credentials[k] = prepareCredentials(values)
+			}
+			continue
+		}
+		obj.data[k] = v
+	}
+
+	if obj.collection == collectionApplications {
+		for _, sp := range s.objects {
+			if sp.collection == collectionServicePrincipals && strings.EqualFold(sp.string("appId"), obj.string("appId")) {
+				s.beginChange(sp)
+				syncServicePrincipal(sp.data, obj.data)
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removedEnabledEntitlement returns the ID of an enabled app role or permission which is missing from desired
+func removedEnabledEntitlement(existing, desired interface{}) string {
+	existingList, _ := existing.([]interface{})
+	desiredList, _ := desired.([]interface{})
+
+	for _, e := range existingList {
+		entitlement, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := entitlement["id"].(string)
+		enabled, _ := entitlement["isEnabled"].(bool)
+		if !enabled {
+			continue
+		}
+
+		found := false
+		for _, d := range desiredList {
+			if m, ok := d.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprintf("%v", m["id"]), id) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) delete(obj *object) {
+	id := obj.id()
+
+	delete(s.objects, id)
+	delete(s.links, id)
+	s.removeReferencesTo(id)
+
+	for _, relations := range s.links {
+		for property, ids := range relations {
+			if i := indexOf(ids, id); i != -1 {
+				relations[property] = append(ids[:i], ids[i+1:]...)
+			}
+		}
+	}
+
+	// deleting an Application also deletes its Service Principal
+	if obj.collection == collectionApplications {
+		for _, sp := range s.objects {
+			if sp.collection == collectionServicePrincipals && strings.EqualFold(sp.string("appId"), obj.string("appId")) {
+				s.delete(sp)
+			}
+		}
+	}
+}
+
+func (s *Server) handleCredentials(w http.ResponseWriter, r *http.Request, obj *object, property string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"value": obj.viewCredentials(property),
+		})
+	case http.MethodPatch:
+		values, ok := body["value"].([]interface{})
+		if !ok {
+			values = make([]interface{}, 0)
+		}
+		s.beginChange(obj)
+		obj.credentials[property] = prepareCredentials(values)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Request_BadRequest", fmt.Sprintf("%s is not supported for %s", r.Method, property))
+	}
+}
+
+// prepareCredentials defaults the start date of credentials to now, as the API does
+func prepareCredentials(values []interface{}) []interface{} {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, v := range values {
+		if credential, ok := v.(map[string]interface{}); ok {
+			if startDate, _ := credential["startDate"].(string); startDate == "" {
+				credential["startDate"] = now
+			}
+		}
+	}
+
+	return values
+}
+
+func (s *Server) listLinks(w http.ResponseWriter, obj *object, property string) {
+	values := make([]interface{}, 0)
+	for _, id := range s.links[obj.id()][property] {
+		if linked := s.findAny(id); linked != nil {
+			values = append(values, linked.view())
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) addLink(w http.ResponseWriter, obj *object, property string, body map[string]interface{}) {
+	url, _ := body["url"].(string)
+	id := url[strings.LastIndex(url, "/")+1:]
+
+	linked := s.findAny(id)
+	if linked == nil {
+		writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id))
+		return
+	}
+
+	if _, ok := s.links[obj.id()]; !ok {
+		s.links[obj.id()] = make(map[string][]string)
+	}
+
+	if indexOf(s.links[obj.id()][property], linked.id()) != -1 {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", fmt.Sprintf("One or more added object references already exist for the following modified properties: '%s'.", property))
+		return
+	}
+
+	s.links[obj.id()][property] = append(s.links[obj.id()][property], linked.id())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeLink(w http.ResponseWriter, obj *object, property, id string) {
+	ids := s.links[obj.id()][property]
+
+	i := indexOf(ids, id)
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id))
+		return
+	}
+
+	s.links[obj.id()][property] = append(ids[:i], ids[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setDefault(data map[string]interface{}, key string, value interface{}) {
+	if _, ok := data[key]; !ok {
+		data[key] = value
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if strings.EqualFold(v, value) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,333 @@
+// Package fakegraph provides an in-process, stateful fake of the parts of the Azure Active Directory Graph API
+// (graph.windows.net, api-version 1.6) which are used by the provider, allowing the acceptance tests to be run
+// without access to a real tenant.
+package fakegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fault describes an error response which the server returns in place of handling a request
+type Fault struct {
+	// Method is the HTTP method to match, an empty value matches any method
+	Method string
+
+	// Path is matched against the path of the request relative to the tenant, e.g. `/applications/{objectId}`
+	Path *regexp.Regexp
+
+	// StatusCode is the status code of the response, e.g. http.StatusNotFound or http.StatusTooManyRequests
+	StatusCode int
+
+	// RetryAfter is the number of seconds returned in the `Retry-After` header, only used when non-zero
+	RetryAfter int
+
+	// Times is the number of requests the fault applies to, after which it's removed. Zero means forever.
+	Times int
+}
+
+// Server is a fake Graph API listening on a local address
+type Server struct {
+	*httptest.Server
+
+	TenantID string
+
+	// CallerObjectID is the Object ID of the Service Principal the requests are made as, which the API adds as an
+	// owner of the Applications it creates
+	CallerObjectID string
+
+	mu                     sync.Mutex
+	objects                map[string]*object
+	links                  map[string]map[string][]string
+	appRoleAssignments     map[string]map[string]interface{}
+	oauth2PermissionGrants map[string]map[string]interface{}
+	domains                []map[string]interface{}
+	faults                 []*Fault
+	replicationDelay       time.Duration
+	pageSize               int
+	pages                  map[string]listPage
+	nextPage               int
+	sequence               int
+}
+
+type listPage struct {
+	filter string
+	offset int
+}
+
+// NewServer starts a new fake Graph API for the given tenant, which should be closed once finished with
+func NewServer(tenantID string) *Server {
+	s := &Server{
+		TenantID:               tenantID,
+		objects:                make(map[string]*object),
+		links:                  make(map[string]map[string][]string),
+		appRoleAssignments:     make(map[string]map[string]interface{}),
+		oauth2PermissionGrants: make(map[string]map[string]interface{}),
+		pageSize:               100,
+		pages:                  make(map[string]listPage),
+		domains: []map[string]interface{}{
+			{
+				"authenticationType": "Managed",
+				"isDefault":          true,
+				"isInitial":          true,
+				"isVerified":         true,
+				"name":               "fakegraph.onmicrosoft.com",
+			},
+		},
+	}
+	s.addCaller()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint returns the base URI which should be used in place of the Graph endpoint of the environment
+func (s *Server) Endpoint() string {
+	return s.URL + "/"
+}
+
+// InjectFault registers a fault, faults are matched in the order they were injected
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := fault
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// SetReplicationDelay sets how long newly created objects are invisible for, and how long reads continue to return
+// the previous state of changed objects, mimicking the eventual consistency of the real API
+func (s *Server) SetReplicationDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replicationDelay = delay
+}
+
+// SetPageSize sets the maximum number of objects returned in a single page of a list
+func (s *Server) SetPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pageSize = size
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], s.TenantID) {
+		writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Unknown tenant or path %q", r.URL.Path))
+		return
+	}
+	segments = segments[1:]
+
+	if fault := s.matchFault(r.Method, "/"+strings.Join(segments, "/")); fault != nil {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+		}
+		writeError(w, fault.StatusCode, "Fake_InjectedFault", fmt.Sprintf("Injected fault for %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPatch) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", fmt.Sprintf("Invalid JSON body: %+v", err))
+			return
+		}
+	}
+
+	s.route(w, r, segments, body)
+}
+
+func (s *Server) matchFault(method, path string) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		if f.Path != nil && !f.Path.MatchString(path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+
+	return nil
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string, body map[string]interface{}) {
+	collection := segments[0]
+
+	switch collection {
+	case "domains":
+		s.handleDomains(w, r, segments)
+		return
+	case "isMemberOf":
+		if r.Method == http.MethodPost {
+			s.handleIsMemberOf(w, body)
+			return
+		}
+	case "getObjectsByObjectIds":
+		if r.Method == http.MethodPost {
+			s.handleGetObjectsByObjectIds(w, body)
+			return
+		}
+	case "oauth2PermissionGrants":
+		s.handleOAuth2PermissionGrants(w, r, segments[1:], body)
+		return
+	case collectionApplications, collectionGroups, collectionServicePrincipals, collectionUsers:
+		s.handleCollection(w, r, collection, segments[1:], body)
+		return
+	}
+
+	writeError(w, http.StatusNotImplemented, "Fake_NotImplemented", fmt.Sprintf("%s %s is not implemented by the fake Graph API", r.Method, r.URL.Path))
+}
+
+func (s *Server) handleCollection(w http.ResponseWriter, r *http.Request, collection string, segments []string, body map[string]interface{}) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, collection)
+			return
+		case http.MethodPost:
+			s.create(w, collection, body)
+			return
+		}
+	}
+
+	if len(segments) >= 1 {
+		obj := s.find(collection, segments[0])
+		if obj == nil {
+			writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", segments[0]))
+			return
+		}
+
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, obj.view())
+			return
+		case len(segments) == 1 && r.Method == http.MethodPatch:
+			s.update(w, obj, body)
+			return
+		case len(segments) == 1 && r.Method == http.MethodDelete:
+			s.delete(obj)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case len(segments) == 2 && isCredentialsProperty(segments[1]):
+			s.handleCredentials(w, r, obj, segments[1], body)
+			return
+		case len(segments) == 2 && isLinkProperty(collection, segments[1]) && r.Method == http.MethodGet:
+			s.listLinks(w, obj, segments[1])
+			return
+		case len(segments) >= 2 && segments[1] == "appRoleAssignments" && obj.collection != collectionApplications:
+			s.handleAppRoleAssignments(w, r, obj, segments[2:], body)
+			return
+		case len(segments) == 3 && segments[1] == "$links" && isLinkProperty(collection, segments[2]) && r.Method == http.MethodPost:
+			s.addLink(w, obj, segments[2], body)
+			return
+		case len(segments) == 4 && segments[1] == "$links" && isLinkProperty(collection, segments[2]) && r.Method == http.MethodDelete:
+			s.removeLink(w, obj, segments[2], segments[3])
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotImplemented, "Fake_NotImplemented", fmt.Sprintf("%s %s is not implemented by the fake Graph API", r.Method, r.URL.Path))
+}
+
+func (s *Server) handleDomains(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Request_BadRequest", "Domains are read only")
+		return
+	}
+
+	if len(segments) == 2 {
+		for _, d := range s.domains {
+			if strings.EqualFold(d["name"].(string), segments[1]) {
+				writeJSON(w, http.StatusOK, d)
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Domain %q was not found", segments[1]))
+		return
+	}
+
+	values := make([]interface{}, 0, len(s.domains))
+	for _, d := range s.domains {
+		values = append(values, d)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) handleIsMemberOf(w http.ResponseWriter, body map[string]interface{}) {
+	groupId, _ := body["groupId"].(string)
+	memberId, _ := body["memberId"].(string)
+
+	if s.find(collectionGroups, groupId) == nil {
+		writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", groupId))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": indexOf(s.links[groupId]["members"], memberId) != -1,
+	})
+}
+
+func (s *Server) handleGetObjectsByObjectIds(w http.ResponseWriter, body map[string]interface{}) {
+	values := make([]interface{}, 0)
+
+	if ids, ok := body["objectIds"].([]interface{}); ok {
+		for _, v := range ids {
+			id, _ := v.(string)
+			if obj := s.findAny(id); obj != nil {
+				values = append(values, obj.view())
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; odata=minimalmetadata; streaming=true; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v) // nolint: errcheck
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"odata.error": map[string]interface{}{
+			"code": code,
+			"message": map[string]interface{}{
+				"lang":  "en",
+				"value": message,
+			},
+		},
+	})
+}
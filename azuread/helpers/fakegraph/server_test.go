@@ -0,0 +1,269 @@
+package fakegraph
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+const testTenantID = "00000000-0000-0000-0000-000000000000"
+
+func testGroupsClient(s *Server) graphrbac.GroupsClient {
+	client := graphrbac.NewGroupsClientWithBaseURI(s.Endpoint(), s.TenantID)
+	client.Authorizer = autorest.NullAuthorizer{}
+	return client
+}
+
+func testCreateGroup(t *testing.T, client graphrbac.GroupsClient, name string) graphrbac.ADGroup {
+	group, err := client.Create(context.Background(), graphrbac.GroupCreateParameters{
+		DisplayName:     &name,
+		MailEnabled:     p.Bool(false),
+		MailNickname:    &name,
+		SecurityEnabled: p.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Group %q: %+v", name, err)
+	}
+
+	return group
+}
+
+func TestServer_groupLifecycle(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := testGroupsClient(s)
+	ctx := context.Background()
+
+	group := testCreateGroup(t, client, "acctest")
+	if group.ObjectID == nil || *group.ObjectID == "" {
+		t.Fatalf("Expected the Group to have an Object ID")
+	}
+
+	read, err := client.Get(ctx, *group.ObjectID)
+	if err != nil {
+		t.Fatalf("Error retrieving Group: %+v", err)
+	}
+	if *read.DisplayName != "acctest" {
+		t.Fatalf("Expected display name %q but got %q", "acctest", *read.DisplayName)
+	}
+
+	if _, err := client.Delete(ctx, *group.ObjectID); err != nil {
+		t.Fatalf("Error deleting Group: %+v", err)
+	}
+
+	resp, err := client.Get(ctx, *group.ObjectID)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected a 404 retrieving a deleted Group but got %d: %+v", resp.StatusCode, err)
+	}
+}
+
+func TestServer_listPaging(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+	s.SetPageSize(2)
+
+	client := testGroupsClient(s)
+	ctx := context.Background()
+
+	for _, name := range []string{"acctest-a", "acctest-b", "acctest-c", "acctest-d", "acctest-e", "other"} {
+		testCreateGroup(t, client, name)
+	}
+
+	iterator, err := client.ListComplete(ctx, "startswith(displayName,'acctest-')")
+	if err != nil {
+		t.Fatalf("Error listing Groups: %+v", err)
+	}
+
+	names := make([]string, 0)
+	for iterator.NotDone() {
+		names = append(names, *iterator.Value().DisplayName)
+		if err := iterator.NextWithContext(ctx); err != nil {
+			t.Fatalf("Error listing Groups: %+v", err)
+		}
+	}
+
+	if len(names) != 5 {
+		t.Fatalf("Expected 5 Groups but got %d: %v", len(names), names)
+	}
+	for i, name := range names {
+		if expected := "acctest-" + string(rune('a'+i)); name != expected {
+			t.Fatalf("Expected Group %d to be %q but got %q", i, expected, name)
+		}
+	}
+}
+
+func TestServer_injectedNotFound(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := testGroupsClient(s)
+	ctx := context.Background()
+
+	group := testCreateGroup(t, client, "acctest")
+
+	s.InjectFault(Fault{
+		Method:     http.MethodGet,
+		Path:       regexp.MustCompile("^/groups/"),
+		StatusCode: http.StatusNotFound,
+		Times:      1,
+	})
+
+	resp, err := client.Get(ctx, *group.ObjectID)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected an injected 404 but got %d: %+v", resp.StatusCode, err)
+	}
+
+	if _, err := client.Get(ctx, *group.ObjectID); err != nil {
+		t.Fatalf("Expected the fault to have been removed: %+v", err)
+	}
+}
+
+func TestServer_injectedThrottling(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := testGroupsClient(s)
+	ctx := context.Background()
+
+	group := testCreateGroup(t, client, "acctest")
+
+	s.InjectFault(Fault{
+		Path:       regexp.MustCompile("^/groups/"),
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 1,
+		Times:      1,
+	})
+
+	// the SDK retries throttled requests after the delay given in the Retry-After header
+	start := time.Now()
+	if _, err := client.Get(ctx, *group.ObjectID); err != nil {
+		t.Fatalf("Expected the throttled request to be retried: %+v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the retry to honour the Retry-After header, but it took %s", elapsed)
+	}
+}
+
+func TestServer_replicationDelay(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+	s.SetReplicationDelay(500 * time.Millisecond)
+
+	client := testGroupsClient(s)
+	ctx := context.Background()
+
+	group := testCreateGroup(t, client, "acctest")
+
+	resp, err := client.Get(ctx, *group.ObjectID)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected a 404 before the Group has replicated but got %d: %+v", resp.StatusCode, err)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	if _, err := client.Get(ctx, *group.ObjectID); err != nil {
+		t.Fatalf("Expected the Group to be visible once replicated: %+v", err)
+	}
+}
+
+func TestServer_servicePrincipalRequiresApplication(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := graphrbac.NewServicePrincipalsClientWithBaseURI(s.Endpoint(), s.TenantID)
+	client.Authorizer = autorest.NullAuthorizer{}
+
+	appId := "11111111-1111-1111-1111-111111111111"
+	resp, err := client.Create(context.Background(), graphrbac.ServicePrincipalCreateParameters{
+		AppID: &appId,
+	})
+	if err == nil || resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected a 400 creating a Service Principal without an Application but got %d: %+v", resp.StatusCode, err)
+	}
+}
+
+func TestServer_replicationDelayOnUpdate(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := graphrbac.NewApplicationsClientWithBaseURI(s.Endpoint(), s.TenantID)
+	client.Authorizer = autorest.NullAuthorizer{}
+	ctx := context.Background()
+
+	app, err := client.Create(ctx, graphrbac.ApplicationCreateParameters{
+		AvailableToOtherTenants: p.Bool(false),
+		DisplayName:             p.String("before"),
+		IdentifierUris:          &[]string{},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Application: %+v", err)
+	}
+
+	s.SetReplicationDelay(500 * time.Millisecond)
+
+	if _, err := client.Patch(ctx, *app.ObjectID, graphrbac.ApplicationUpdateParameters{DisplayName: p.String("after")}); err != nil {
+		t.Fatalf("Error updating Application: %+v", err)
+	}
+
+	read, err := client.Get(ctx, *app.ObjectID)
+	if err != nil {
+		t.Fatalf("Expected the Application to remain visible while the change replicates: %+v", err)
+	}
+	if *read.DisplayName != "before" {
+		t.Fatalf("Expected the previous display name before the change has replicated but got %q", *read.DisplayName)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	read, err = client.Get(ctx, *app.ObjectID)
+	if err != nil {
+		t.Fatalf("Error retrieving Application: %+v", err)
+	}
+	if *read.DisplayName != "after" {
+		t.Fatalf("Expected the new display name once the change has replicated but got %q", *read.DisplayName)
+	}
+}
+
+func TestServer_applicationOwnedByCaller(t *testing.T) {
+	s := NewServer(testTenantID)
+	defer s.Close()
+
+	client := graphrbac.NewApplicationsClientWithBaseURI(s.Endpoint(), s.TenantID)
+	client.Authorizer = autorest.NullAuthorizer{}
+	ctx := context.Background()
+
+	app, err := client.Create(ctx, graphrbac.ApplicationCreateParameters{
+		AvailableToOtherTenants: p.Bool(false),
+		DisplayName:             p.String("acctest"),
+		IdentifierUris:          &[]string{},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Application: %+v", err)
+	}
+
+	owners, err := client.ListOwnersComplete(ctx, *app.ObjectID)
+	if err != nil {
+		t.Fatalf("Error listing owners of Application: %+v", err)
+	}
+
+	ids := make([]string, 0)
+	for owners.NotDone() {
+		if owner, ok := owners.Value().AsServicePrincipal(); ok && owner.ObjectID != nil {
+			ids = append(ids, *owner.ObjectID)
+		}
+		if err := owners.NextWithContext(ctx); err != nil {
+			t.Fatalf("Error listing owners of Application: %+v", err)
+		}
+	}
+
+	if len(ids) != 1 || ids[0] != s.CallerObjectID {
+		t.Fatalf("Expected the caller %q to be the only owner of the Application but got %v", s.CallerObjectID, ids)
+	}
+}
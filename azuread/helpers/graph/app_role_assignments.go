@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-uuid"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+// the vendored graphrbac SDK has no support for App Role Assignments, so this client follows the same
+// conventions as the generated clients to manage them via the `appRoleAssignments` navigation property
+
+// DefaultAccessAppRoleId is the ID assigned in place of an App Role to grant access to an application which doesn't
+// define any App Roles, it's never listed in the App Roles of the resource Service Principal
+const DefaultAccessAppRoleId = "00000000-0000-0000-0000-000000000000"
+
+// AppRoleAssignment assigns an App Role exposed by a resource Service Principal to a User, Group or Service Principal
+type AppRoleAssignment struct {
+	autorest.Response `json:"-"`
+	// ObjectID - The ID of the assignment itself
+	ObjectID *string `json:"objectId,omitempty"`
+	// ID - The ID of the App Role being assigned, the zero UUID represents the default access role
+	ID *string `json:"id,omitempty"`
+	// PrincipalID - The Object ID of the User, Group or Service Principal being assigned the App Role
+	PrincipalID *string `json:"principalId,omitempty"`
+	// PrincipalType - Possible values include: 'User', 'Group', 'ServicePrincipal'
+	PrincipalType *string `json:"principalType,omitempty"`
+	// PrincipalDisplayName - The display name of the principal
+	PrincipalDisplayName *string `json:"principalDisplayName,omitempty"`
+	// ResourceID - The Object ID of the resource Service Principal which exposes the App Role
+	ResourceID *string `json:"resourceId,omitempty"`
+	// ResourceDisplayName - The display name of the resource Service Principal
+	ResourceDisplayName *string `json:"resourceDisplayName,omitempty"`
+	// CreationTimestamp - The time when the assignment was created
+	CreationTimestamp *date.Time `json:"creationTimestamp,omitempty"`
+}
+
+// AppRoleAssignmentListResult is a single page of App Role Assignments
+type AppRoleAssignmentListResult struct {
+	autorest.Response `json:"-"`
+	Value             *[]AppRoleAssignment `json:"value,omitempty"`
+	OdataNextLink     *string              `json:"odata.nextLink,omitempty"`
+}
+
+type AppRoleAssignmentsClient struct {
+	graphrbac.BaseClient
+}
+
+func NewAppRoleAssignmentsClientWithBaseURI(baseURI string, tenantID string) AppRoleAssignmentsClient {
+	return AppRoleAssignmentsClient{graphrbac.NewWithBaseURI(baseURI, tenantID)}
+}
+
+// PrincipalCollection maps the object type of a principal to the collection it's found in
+func PrincipalCollection(principalType graphrbac.ObjectType) (string, error) {
+	switch principalType {
+	case graphrbac.ObjectTypeUser:
+		return "users", nil
+	case graphrbac.ObjectTypeGroup:
+		return "groups", nil
+	case graphrbac.ObjectTypeServicePrincipal:
+		return "servicePrincipals", nil
+	}
+
+	return "", fmt.Errorf("App Roles cannot be assigned to objects of type %q", principalType)
+}
+
+// Create assigns an App Role to the specified principal
+func (client AppRoleAssignmentsClient) Create(ctx context.Context, principalType graphrbac.ObjectType, parameters AppRoleAssignment) (result AppRoleAssignment, err error) {
+	if parameters.PrincipalID == nil {
+		return result, fmt.Errorf("graph.AppRoleAssignmentsClient#Create: `PrincipalID` must be specified")
+	}
+
+	collection, err := PrincipalCollection(principalType)
+	if err != nil {
+		return result, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"collection":  collection,
+		"principalId": autorest.Encode("path", *parameters.PrincipalID),
+		"tenantID":    autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/{collection}/{principalId}/appRoleAssignments", pathParameters),
+		autorest.WithJSON(parameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Create", nil, "Failure preparing request")
+	}
+
+	resp, err := client.send(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Create", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusCreated),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Create", resp, "Failure responding to request")
+	}
+
+	return result, err
+}
+
+// Delete removes an App Role Assignment from the specified principal
+func (client AppRoleAssignmentsClient) Delete(ctx context.Context, principalType graphrbac.ObjectType, principalId, assignmentId string) (result autorest.Response, err error) {
+	collection, err := PrincipalCollection(principalType)
+	if err != nil {
+		return result, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"assignmentId": autorest.Encode("path", assignmentId),
+		"collection":   collection,
+		"principalId":  autorest.Encode("path", principalId),
+		"tenantID":     autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsDelete(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/{collection}/{principalId}/appRoleAssignments/{assignmentId}", pathParameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Delete", nil, "Failure preparing request")
+	}
+
+	resp, err := client.send(req)
+	if err != nil {
+		result.Response = resp
+		return result, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Delete", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNoContent),
+		autorest.ByClosing())
+	result.Response = resp
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "Delete", resp, "Failure responding to request")
+	}
+
+	return result, err
+}
+
+// ListComplete returns every App Role Assignment of the specified principal, crossing page boundaries as required
+func (client AppRoleAssignmentsClient) ListComplete(ctx context.Context, principalType graphrbac.ObjectType, principalId string) (result []AppRoleAssignment, err error) {
+	collection, err := PrincipalCollection(principalType)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"collection":  collection,
+		"principalId": autorest.Encode("path", principalId),
+		"tenantID":    autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/{collection}/{principalId}/appRoleAssignments", pathParameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+
+	result = make([]AppRoleAssignment, 0)
+	for {
+		req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+		if err != nil {
+			return nil, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "List", nil, "Failure preparing request")
+		}
+
+		page, err := client.list(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if page.Value != nil {
+			result = append(result, *page.Value...)
+		}
+
+		if page.OdataNextLink == nil || *page.OdataNextLink == "" {
+			break
+		}
+
+		preparer = nextLinkPreparer(client.BaseURI, client.TenantID, *page.OdataNextLink)
+	}
+
+	return result, nil
+}
+
+func (client AppRoleAssignmentsClient) list(req *http.Request) (result AppRoleAssignmentListResult, err error) {
+	resp, err := client.send(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "List", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "graph.AppRoleAssignmentsClient", "List", resp, "Failure responding to request")
+	}
+
+	return result, err
+}
+
+func (client AppRoleAssignmentsClient) send(req *http.Request) (*http.Response, error) {
+	return autorest.SendWithSender(client, req,
+		autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+type AppRoleAssignmentId struct {
+	PrincipalId  string
+	AssignmentId string
+}
+
+func (id AppRoleAssignmentId) String() string {
+	return id.PrincipalId + "/" + id.AssignmentId
+}
+
+func ParseAppRoleAssignmentId(id string) (AppRoleAssignmentId, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return AppRoleAssignmentId{}, fmt.Errorf("App Role Assignment ID should be in the format {principalObjectId}/{assignmentId} - but got %q", id)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return AppRoleAssignmentId{}, fmt.Errorf("Principal Object ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	// assignment IDs are not UUIDs
+	if parts[1] == "" {
+		return AppRoleAssignmentId{}, fmt.Errorf("Assignment ID must not be empty (%q)", id)
+	}
+
+	return AppRoleAssignmentId{
+		PrincipalId:  parts[0],
+		AssignmentId: parts[1],
+	}, nil
+}
+
+func AppRoleAssignmentIdFrom(principalId, assignmentId string) AppRoleAssignmentId {
+	return AppRoleAssignmentId{
+		PrincipalId:  principalId,
+		AssignmentId: assignmentId,
+	}
+}
+
+func AppRoleAssignmentFindById(assignments []AppRoleAssignment, id string) *AppRoleAssignment {
+	for _, a := range assignments {
+		if a.ObjectID != nil && *a.ObjectID == id {
+			return &a
+		}
+	}
+
+	return nil
+}
+
+// DirectoryObjectType looks up the type of the directory object with the specified Object ID
+func DirectoryObjectType(client ObjectsAPI, ctx context.Context, objectId string) (graphrbac.ObjectType, error) {
+	properties := graphrbac.GetObjectsParameters{
+		ObjectIds:                        &[]string{objectId},
+		IncludeDirectoryObjectReferences: p.Bool(true),
+	}
+
+	page, err := client.GetObjectsByObjectIds(ctx, properties)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Directory Object %q: %+v", objectId, err)
+	}
+
+	for _, v := range page.Values() {
+		if id := DirectoryObjectID(v); id != nil && strings.EqualFold(*id, objectId) {
+			if _, ok := v.AsUser(); ok {
+				return graphrbac.ObjectTypeUser, nil
+			}
+			if _, ok := v.AsADGroup(); ok {
+				return graphrbac.ObjectTypeGroup, nil
+			}
+			if _, ok := v.AsServicePrincipal(); ok {
+				return graphrbac.ObjectTypeServicePrincipal, nil
+			}
+			if _, ok := v.AsApplication(); ok {
+				return graphrbac.ObjectTypeApplication, nil
+			}
+			return graphrbac.ObjectTypeDirectoryObject, nil
+		}
+	}
+
+	return "", nil
+}
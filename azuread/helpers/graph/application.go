@@ -0,0 +1,390 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-uuid"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+type ApplicationOwnerId struct {
+	ApplicationId string
+	OwnerId       string
+}
+
+func (id ApplicationOwnerId) String() string {
+	return id.ApplicationId + "/" + id.OwnerId
+}
+
+func ParseApplicationOwnerId(id string) (ApplicationOwnerId, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return ApplicationOwnerId{}, fmt.Errorf("Application Owner ID should be in the format {applicationObjectId}/{ownerObjectId} - but got %q", id)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return ApplicationOwnerId{}, fmt.Errorf("Application Object ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	if _, err := uuid.ParseUUID(parts[1]); err != nil {
+		return ApplicationOwnerId{}, fmt.Errorf("Owner Object ID isn't a valid UUID (%q): %+v", parts[1], err)
+	}
+
+	return ApplicationOwnerId{
+		ApplicationId: parts[0],
+		OwnerId:       parts[1],
+	}, nil
+}
+
+func ApplicationOwnerIdFrom(applicationId, ownerId string) ApplicationOwnerId {
+	return ApplicationOwnerId{
+		ApplicationId: applicationId,
+		OwnerId:       ownerId,
+	}
+}
+
+func ApplicationAllOwners(client ApplicationsAPI, ctx context.Context, appId string) ([]string, error) {
+	it, err := client.ListOwnersComplete(ctx, appId)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Owners of Application %q: %+v", appId, err)
+	}
+
+	owners, err := DirectoryObjectListToIDs(ctx, it)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Owners of Application %q: %+v", appId, err)
+	}
+
+	return owners, nil
+}
+
+func ApplicationAddOwner(client ApplicationsAPI, ctx context.Context, timeout time.Duration, appId, ownerId string) error {
+	properties := graphrbac.AddOwnerParameters{
+		URL: p.String(client.DirectoryObjectURL(ownerId)),
+	}
+
+	// the application or the owner may have only just been created
+	if err := RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		return client.AddOwner(ctx, appId, properties)
+	}); err != nil {
+		return fmt.Errorf("Error adding Owner %q to Application %q: %+v", ownerId, appId, err)
+	}
+
+	return nil
+}
+
+func ApplicationAddOwners(client ApplicationsAPI, ctx context.Context, timeout time.Duration, appId string, owners []string) error {
+	for _, ownerId := range owners {
+		if err := ApplicationAddOwner(client, ctx, timeout, appId, ownerId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ApplicationRemoveOwners(client ApplicationsAPI, ctx context.Context, appId string, owners []string) error {
+	for _, ownerId := range owners {
+		if resp, err := client.RemoveOwner(ctx, appId, ownerId); err != nil {
+			if !ar.ResponseWasNotFound(resp) {
+				return fmt.Errorf("Error removing Owner %q from Application %q: %+v", ownerId, appId, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplicationFindByDisplayName returns the first Application with exactly the given display name, or nil when there
+// is none
+func ApplicationFindByDisplayName(client ApplicationsAPI, ctx context.Context, displayName string) (*graphrbac.Application, error) {
+	filter := fmt.Sprintf("displayName eq '%s'", ODataEscape(displayName))
+
+	it, err := client.ListComplete(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Applications with filter %q: %+v", filter, err)
+	}
+
+	for it.NotDone() {
+		if app := it.Value(); app.DisplayName != nil && *app.DisplayName == displayName {
+			return &app, nil
+		}
+
+		if err := it.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Applications with filter %q: %+v", filter, err)
+		}
+	}
+
+	return nil, nil
+}
+
+// ApplicationWaitForOwners waits until the owners of an application include every object in `present` and none in `absent`
+func ApplicationWaitForOwners(client ApplicationsAPI, ctx context.Context, timeout time.Duration, appId string, present, absent []string) error {
+	if err := WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		owners, err := ApplicationAllOwners(client, ctx, appId)
+		return autorest.Response{}, err == nil && DirectoryObjectIdsReplicated(owners, present, absent), err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for the Owners of Application %q to replicate: %+v", appId, err)
+	}
+
+	return nil
+}
+
+// ApplicationWaitForChanges waits until the properties of an update are visible on the application
+func ApplicationWaitForChanges(client ApplicationsAPI, ctx context.Context, timeout time.Duration, appId string, properties graphrbac.ApplicationUpdateParameters) error {
+	if err := WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		app, err := client.Get(ctx, appId)
+		return app.Response, err == nil && ApplicationChangesReplicated(properties, app), err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for changes to Application %q to replicate: %+v", appId, err)
+	}
+
+	return nil
+}
+
+func AppRoleFindByValue(roles *[]graphrbac.AppRole, value *string) *graphrbac.AppRole {
+	if roles == nil || value == nil {
+		return nil
+	}
+
+	for _, r := range *roles {
+		if r.Value != nil && *r.Value == *value {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// AppRoleFindByDisplayNameWithoutValue returns the role with the display name, among the roles which have no value
+func AppRoleFindByDisplayNameWithoutValue(roles *[]graphrbac.AppRole, displayName *string) *graphrbac.AppRole {
+	if roles == nil || displayName == nil {
+		return nil
+	}
+
+	for _, r := range *roles {
+		if (r.Value == nil || *r.Value == "") && r.DisplayName != nil && *r.DisplayName == *displayName {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+func AppRoleFindById(roles *[]graphrbac.AppRole, id string) *graphrbac.AppRole {
+	if roles == nil {
+		return nil
+	}
+
+	for _, r := range *roles {
+		if r.ID != nil && strings.EqualFold(*r.ID, id) {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// AppRolesDisableRemoved returns the existing roles with any role missing from `desired` disabled, since the API
+// refuses to remove a role which is still enabled. The boolean is false when there is nothing to disable.
+func AppRolesDisableRemoved(existing *[]graphrbac.AppRole, desired *[]graphrbac.AppRole) (*[]graphrbac.AppRole, bool) {
+	if existing == nil {
+		return nil, false
+	}
+
+	desiredIds := make(map[string]bool)
+	if desired != nil {
+		for _, r := range *desired {
+			if r.ID != nil {
+				desiredIds[*r.ID] = true
+			}
+		}
+	}
+
+	changed := false
+	result := make([]graphrbac.AppRole, 0, len(*existing))
+	for _, r := range *existing {
+		if r.ID != nil && !desiredIds[*r.ID] && (r.IsEnabled == nil || *r.IsEnabled) {
+			r.IsEnabled = p.Bool(false)
+			changed = true
+		}
+		result = append(result, r)
+	}
+
+	return &result, changed
+}
+
+// OAuth2 Permissions aren't modelled by the SDK so are handled as the raw maps found in AdditionalProperties
+func OAuth2PermissionFindIdByValue(permissions []interface{}, value string) string {
+	for _, raw := range permissions {
+		permission, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if v, ok := permission["value"].(string); ok && v == value {
+			if id, ok := permission["id"].(string); ok {
+				return id
+			}
+		}
+	}
+
+	return ""
+}
+
+// OAuth2PermissionsDisableRemoved returns the existing permissions with any permission missing from `desired` disabled,
+// since the API refuses to remove a permission which is still enabled. The boolean is false when there is nothing to disable.
+func OAuth2PermissionsDisableRemoved(existing []interface{}, desired []interface{}) ([]interface{}, bool) {
+	desiredIds := make(map[string]bool)
+	for _, raw := range desired {
+		if permission, ok := raw.(map[string]interface{}); ok {
+			if id, ok := permission["id"].(string); ok {
+				desiredIds[id] = true
+			}
+		}
+	}
+
+	changed := false
+	result := make([]interface{}, 0, len(existing))
+	for _, raw := range existing {
+		permission, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := permission["id"].(string)
+		if enabled, ok := permission["isEnabled"].(bool); !desiredIds[id] && (!ok || enabled) {
+			permission["isEnabled"] = false
+			changed = true
+		}
+		result = append(result, permission)
+	}
+
+	return result, changed
+}
+
+// ApplicationChangesReplicated returns whether the properties of an update are reflected by the application
+func ApplicationChangesReplicated(properties graphrbac.ApplicationUpdateParameters, app graphrbac.Application) bool {
+	if properties.DisplayName != nil && !stringPtrEqual(properties.DisplayName, app.DisplayName) {
+		return false
+	}
+	if properties.Homepage != nil && !stringPtrEqual(properties.Homepage, app.Homepage) {
+		return false
+	}
+	if properties.AvailableToOtherTenants != nil && !boolPtrEqual(properties.AvailableToOtherTenants, app.AvailableToOtherTenants) {
+		return false
+	}
+	if properties.Oauth2AllowImplicitFlow != nil && !boolPtrEqual(properties.Oauth2AllowImplicitFlow, app.Oauth2AllowImplicitFlow) {
+		return false
+	}
+	if properties.IdentifierUris != nil && !stringSlicesEquivalent(*properties.IdentifierUris, stringSliceValue(app.IdentifierUris)) {
+		return false
+	}
+	if properties.ReplyUrls != nil && !stringSlicesEquivalent(*properties.ReplyUrls, stringSliceValue(app.ReplyUrls)) {
+		return false
+	}
+
+	if properties.AppRoles != nil {
+		ids := make([]string, 0)
+		for _, r := range *properties.AppRoles {
+			if r.ID != nil {
+				ids = append(ids, *r.ID)
+			}
+		}
+		if !stringSlicesEquivalent(ids, appRoleIds(app.AppRoles)) {
+			return false
+		}
+	}
+
+	for _, property := range []string{"groupMembershipClaims", "publicClient"} {
+		if v, ok := properties.AdditionalProperties[property]; ok && fmt.Sprintf("%v", v) != fmt.Sprintf("%v", app.AdditionalProperties[property]) {
+			return false
+		}
+	}
+
+	if v, ok := properties.AdditionalProperties["oauth2Permissions"]; ok {
+		desired, _ := v.([]interface{})
+		existing, _ := app.AdditionalProperties["oauth2Permissions"].([]interface{})
+		if !stringSlicesEquivalent(oauth2PermissionIds(desired), oauth2PermissionIds(existing)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func appRoleIds(roles *[]graphrbac.AppRole) []string {
+	ids := make([]string, 0)
+	if roles != nil {
+		for _, r := range *roles {
+			if r.ID != nil {
+				ids = append(ids, *r.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+func oauth2PermissionIds(permissions []interface{}) []string {
+	ids := make([]string, 0)
+	for _, raw := range permissions {
+		if permission, ok := raw.(map[string]interface{}); ok {
+			if id, ok := permission["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
+
+func stringPtrEqual(expected, actual *string) bool {
+	// the API returns null for properties which have been cleared
+	if actual == nil {
+		return *expected == ""
+	}
+
+	return *expected == *actual
+}
+
+func boolPtrEqual(expected, actual *bool) bool {
+	if actual == nil {
+		return !*expected
+	}
+
+	return *expected == *actual
+}
+
+func stringSliceValue(input *[]string) []string {
+	if input == nil {
+		return []string{}
+	}
+
+	return *input
+}
+
+// stringSlicesEquivalent returns whether both slices contain the same values regardless of order
+func stringSlicesEquivalent(expected, actual []string) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	values := make(map[string]bool, len(actual))
+	for _, v := range actual {
+		values[v] = true
+	}
+
+	for _, v := range expected {
+		if !values[v] {
+			return false
+		}
+	}
+
+	return true
+}
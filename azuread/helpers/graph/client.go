@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// shared plumbing for the clients in this package which fill the gaps in the vendored graphrbac SDK
+
+func apiVersionQueryParameters() map[string]interface{} {
+	return map[string]interface{}{
+		"api-version": "1.6",
+	}
+}
+
+// nextLinkPreparer returns a preparer for the next page of a list, the next link is relative to the tenant
+// and already contains the path
+func nextLinkPreparer(baseURI, tenantID, nextLink string) autorest.Preparer {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(baseURI),
+		autorest.WithPathParameters("/{tenantID}/{nextLink}", map[string]interface{}{
+			"nextLink": strings.TrimPrefix(nextLink, "/"),
+			"tenantID": autorest.Encode("path", tenantID),
+		}),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// the resources are written against these interfaces rather than the graphrbac clients, so that they can be backed by
+// either Azure AD Graph (graph.windows.net) using the graphrbac SDK, or by Microsoft Graph (graph.microsoft.com) using
+// the clients in the msgraph package. Both use the graphrbac models, so the schemas don't depend on the API in use.
+
+// ApplicationsAPI manages Applications
+type ApplicationsAPI interface {
+	AddOwner(ctx context.Context, applicationObjectID string, parameters graphrbac.AddOwnerParameters) (autorest.Response, error)
+	Create(ctx context.Context, parameters graphrbac.ApplicationCreateParameters) (graphrbac.Application, error)
+	Delete(ctx context.Context, applicationObjectID string) (autorest.Response, error)
+	Get(ctx context.Context, applicationObjectID string) (graphrbac.Application, error)
+	ListComplete(ctx context.Context, filter string) (graphrbac.ApplicationListResultIterator, error)
+	ListKeyCredentials(ctx context.Context, applicationObjectID string) (graphrbac.KeyCredentialListResult, error)
+	ListOwnersComplete(ctx context.Context, applicationObjectID string) (graphrbac.DirectoryObjectListResultIterator, error)
+	ListPasswordCredentials(ctx context.Context, applicationObjectID string) (graphrbac.PasswordCredentialListResult, error)
+	Patch(ctx context.Context, applicationObjectID string, parameters graphrbac.ApplicationUpdateParameters) (autorest.Response, error)
+	RemoveOwner(ctx context.Context, applicationObjectID string, ownerObjectID string) (autorest.Response, error)
+	UpdateKeyCredentials(ctx context.Context, applicationObjectID string, parameters graphrbac.KeyCredentialsUpdateParameters) (autorest.Response, error)
+	UpdatePasswordCredentials(ctx context.Context, applicationObjectID string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error)
+
+	// DirectoryObjectURL returns the URL used to reference an object when adding it as an owner
+	DirectoryObjectURL(objectId string) string
+}
+
+// GroupsAPI manages Groups and their members and owners
+type GroupsAPI interface {
+	AddMember(ctx context.Context, groupObjectID string, parameters graphrbac.GroupAddMemberParameters) (autorest.Response, error)
+	AddOwner(ctx context.Context, objectID string, parameters graphrbac.AddOwnerParameters) (autorest.Response, error)
+	Create(ctx context.Context, parameters graphrbac.GroupCreateParameters) (graphrbac.ADGroup, error)
+	Delete(ctx context.Context, objectID string) (autorest.Response, error)
+	Get(ctx context.Context, objectID string) (graphrbac.ADGroup, error)
+	GetGroupMembersComplete(ctx context.Context, objectID string) (graphrbac.DirectoryObjectListResultIterator, error)
+	IsMemberOf(ctx context.Context, parameters graphrbac.CheckGroupMembershipParameters) (graphrbac.CheckGroupMembershipResult, error)
+	ListComplete(ctx context.Context, filter string) (graphrbac.GroupListResultIterator, error)
+	ListOwnersComplete(ctx context.Context, objectID string) (graphrbac.DirectoryObjectListResultIterator, error)
+	RemoveMember(ctx context.Context, groupObjectID string, memberObjectID string) (autorest.Response, error)
+	RemoveOwner(ctx context.Context, objectID string, ownerObjectID string) (autorest.Response, error)
+
+	// DirectoryObjectURL returns the URL used to reference an object when adding it as a member or owner
+	DirectoryObjectURL(objectId string) string
+}
+
+// ServicePrincipalsAPI manages Service Principals
+type ServicePrincipalsAPI interface {
+	Create(ctx context.Context, parameters graphrbac.ServicePrincipalCreateParameters) (graphrbac.ServicePrincipal, error)
+	Delete(ctx context.Context, objectID string) (autorest.Response, error)
+	Get(ctx context.Context, objectID string) (graphrbac.ServicePrincipal, error)
+	ListComplete(ctx context.Context, filter string) (graphrbac.ServicePrincipalListResultIterator, error)
+	ListKeyCredentials(ctx context.Context, objectID string) (graphrbac.KeyCredentialListResult, error)
+	ListPasswordCredentials(ctx context.Context, objectID string) (graphrbac.PasswordCredentialListResult, error)
+	UpdateKeyCredentials(ctx context.Context, objectID string, parameters graphrbac.KeyCredentialsUpdateParameters) (autorest.Response, error)
+	UpdatePasswordCredentials(ctx context.Context, objectID string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error)
+}
+
+// UsersAPI manages Users
+type UsersAPI interface {
+	Create(ctx context.Context, parameters graphrbac.UserCreateParameters) (graphrbac.User, error)
+	Delete(ctx context.Context, upnOrObjectID string) (autorest.Response, error)
+	Get(ctx context.Context, upnOrObjectID string) (graphrbac.User, error)
+	List(ctx context.Context, filter string) (graphrbac.UserListResultPage, error)
+	Update(ctx context.Context, upnOrObjectID string, parameters graphrbac.UserUpdateParameters) (autorest.Response, error)
+}
+
+// ObjectsAPI retrieves directory objects of any type
+type ObjectsAPI interface {
+	GetObjectsByObjectIds(ctx context.Context, parameters graphrbac.GetObjectsParameters) (graphrbac.DirectoryObjectListResultPage, error)
+}
+
+// DomainsAPI lists the domains of the tenant
+type DomainsAPI interface {
+	List(ctx context.Context, filter string) (graphrbac.DomainListResult, error)
+}
+
+// AppRoleAssignmentsAPI manages the App Roles assigned to Users, Groups and Service Principals
+type AppRoleAssignmentsAPI interface {
+	Create(ctx context.Context, principalType graphrbac.ObjectType, parameters AppRoleAssignment) (AppRoleAssignment, error)
+	Delete(ctx context.Context, principalType graphrbac.ObjectType, principalId, assignmentId string) (autorest.Response, error)
+	ListComplete(ctx context.Context, principalType graphrbac.ObjectType, principalId string) ([]AppRoleAssignment, error)
+}
+
+// OAuth2PermissionGrantsAPI manages delegated permission grants
+type OAuth2PermissionGrantsAPI interface {
+	Create(ctx context.Context, parameters OAuth2PermissionGrant) (OAuth2PermissionGrant, error)
+	Delete(ctx context.Context, objectId string) (autorest.Response, error)
+	Get(ctx context.Context, objectId string) (OAuth2PermissionGrant, error)
+	ListComplete(ctx context.Context, filter string) ([]OAuth2PermissionGrant, error)
+	Update(ctx context.Context, objectId string, parameters OAuth2PermissionGrant) (autorest.Response, error)
+}
+
+// PasswordCredentialAdder is implemented by the Application and Service Principal clients of an API which generates the
+// key ID and value of a password itself rather than accepting them, i.e. Microsoft Graph, so that passwords can't be
+// added by updating the password credentials. The generated value is only returned when the password is added.
+type PasswordCredentialAdder interface {
+	AddPasswordCredential(ctx context.Context, objectID string, credential graphrbac.PasswordCredential) (AddedPasswordCredential, error)
+}
+
+// AddedPasswordCredential is a password added by a PasswordCredentialAdder, including its generated key ID and value
+type AddedPasswordCredential struct {
+	autorest.Response `json:"-"`
+	graphrbac.PasswordCredential
+}
+
+// ApplicationsClient is the Azure AD Graph implementation of ApplicationsAPI
+type ApplicationsClient struct {
+	graphrbac.ApplicationsClient
+}
+
+func NewApplicationsClientWithBaseURI(baseURI string, tenantID string) ApplicationsClient {
+	return ApplicationsClient{graphrbac.NewApplicationsClientWithBaseURI(baseURI, tenantID)}
+}
+
+func (client ApplicationsClient) DirectoryObjectURL(objectId string) string {
+	return DirectoryObjectUrl(client.BaseURI, client.TenantID, objectId)
+}
+
+// GroupsClient is the Azure AD Graph implementation of GroupsAPI
+type GroupsClient struct {
+	graphrbac.GroupsClient
+}
+
+func NewGroupsClientWithBaseURI(baseURI string, tenantID string) GroupsClient {
+	return GroupsClient{graphrbac.NewGroupsClientWithBaseURI(baseURI, tenantID)}
+}
+
+func (client GroupsClient) DirectoryObjectURL(objectId string) string {
+	return DirectoryObjectUrl(client.BaseURI, client.TenantID, objectId)
+}
+
+var (
+	_ ApplicationsAPI           = ApplicationsClient{}
+	_ GroupsAPI                 = GroupsClient{}
+	_ ServicePrincipalsAPI      = graphrbac.ServicePrincipalsClient{}
+	_ UsersAPI                  = graphrbac.UsersClient{}
+	_ ObjectsAPI                = graphrbac.ObjectsClient{}
+	_ DomainsAPI                = graphrbac.DomainsClient{}
+	_ AppRoleAssignmentsAPI     = AppRoleAssignmentsClient{}
+	_ OAuth2PermissionGrantsAPI = OAuth2PermissionGrantsClient{}
+)
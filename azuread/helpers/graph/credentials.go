@@ -1,9 +1,14 @@
 package graph
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"log"
 	"strings"
 	"time"
+	"unicode/utf16"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
 	"github.com/Azure/go-autorest/autorest/date"
@@ -11,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/password"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
@@ -32,14 +38,41 @@ func PasswordResourceSchema(object_type string) map[string]*schema.Schema {
 			ValidateFunc: validate.UUID,
 		},
 
-		"value": {
+		"description": {
 			Type:         schema.TypeString,
-			Required:     true,
+			Optional:     true,
 			ForceNew:     true,
-			Sensitive:    true,
 			ValidateFunc: validate.NoEmptyStrings,
 		},
 
+		"value": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			Sensitive:        true,
+			ValidateFunc:     validate.NoEmptyStrings,
+			DiffSuppressFunc: passwordImportedDiffSuppress,
+		},
+
+		"value_length": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ForceNew:         true,
+			ConflictsWith:    []string{"value"},
+			ValidateFunc:     validation.IntBetween(8, 256),
+			DiffSuppressFunc: passwordImportedDiffSuppress,
+		},
+
+		"value_characters": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ConflictsWith:    []string{"value"},
+			ValidateFunc:     validate.NoEmptyStrings,
+			DiffSuppressFunc: passwordImportedDiffSuppress,
+		},
+
 		"start_date": {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -58,15 +91,124 @@ func PasswordResourceSchema(object_type string) map[string]*schema.Schema {
 		},
 
 		"end_date_relative": {
-			Type:          schema.TypeString,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ConflictsWith:    []string{"end_date"},
+			ValidateFunc:     validate.NoEmptyStrings,
+			DiffSuppressFunc: passwordImportedDiffSuppress,
+		},
+
+		"rotation_days": {
+			Type:          schema.TypeInt,
 			Optional:      true,
-			ForceNew:      true,
 			ConflictsWith: []string{"end_date"},
-			ValidateFunc:  validate.NoEmptyStrings,
+			ValidateFunc:  validation.IntAtLeast(1),
+		},
+
+		"expires_in_days": {
+			Type:     schema.TypeInt,
+			Computed: true,
 		},
 	}
 }
 
+// passwordImportedDiffSuppress ignores changes to the properties which are only known when a password is created once
+// it has been imported, since the value of an existing password can't be read and adopting one would otherwise always
+// replace it. Passwords created by Terraform always have their value in the state.
+func passwordImportedDiffSuppress(_, _, _ string, d *schema.ResourceData) bool {
+	value, _ := d.GetChange("value")
+	return d.Id() != "" && value.(string) == ""
+}
+
+// PasswordResourceCustomizeDiff replaces a password once it expires within `rotation_days`, the end date of the
+// replacement is unknown until it has been created from `end_date_relative`
+func PasswordResourceCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	rotationDays, ok := d.GetOk("rotation_days")
+	if !ok {
+		return nil
+	}
+
+	// a replacement would itself expire within the rotation window, and so be replaced on every plan
+	if v := d.Get("end_date_relative").(string); v != "" {
+		if lifetime, err := time.ParseDuration(v); err == nil && time.Duration(rotationDays.(int))*24*time.Hour >= lifetime {
+			return fmt.Errorf("`rotation_days` (%d) must be less than the lifetime of the password given by `end_date_relative` (%s)", rotationDays.(int), v)
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	endDate, err := time.Parse(time.RFC3339, d.Get("end_date").(string))
+	if err != nil {
+		return nil
+	}
+
+	if PasswordCredentialExpiresInDays(endDate) >= rotationDays.(int) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Password Credential %q expires at %s which is within %d days - replacing", d.Id(), endDate.Format(time.RFC3339), rotationDays.(int))
+	if err := d.SetNewComputed("end_date"); err != nil {
+		return err
+	}
+	return d.ForceNew("end_date")
+}
+
+// PasswordResourceIgnoredProperties returns the configured properties of a password which are ignored by an API that
+// generates the key ID and value of passwords itself, i.e. Microsoft Graph
+func PasswordResourceIgnoredProperties(d interface {
+	GetOk(string) (interface{}, bool)
+}) []string {
+	ignored := make([]string, 0)
+	for _, k := range []string{"key_id", "value", "value_length", "value_characters"} {
+		if _, ok := d.GetOk(k); ok {
+			ignored = append(ignored, k)
+		}
+	}
+
+	return ignored
+}
+
+// PasswordResourceCustomizeDiffGenerated plans a password for an API which generates its key ID and value itself, i.e.
+// Microsoft Graph. Any key ID or value which is configured is ignored with a warning, so the key ID and value of a new
+// password are unknown until it's created, and an existing password isn't replaced for differing from them.
+func PasswordResourceCustomizeDiffGenerated(d *schema.ResourceDiff) error {
+	if d.Id() != "" {
+		for _, k := range []string{"key_id", "value"} {
+			if d.HasChange(k) {
+				if err := d.Clear(k); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if ignored := PasswordResourceIgnoredProperties(d); len(ignored) > 0 {
+		log.Printf("[WARN] %s are ignored when using Microsoft Graph, which generates the key ID and value of passwords itself", strings.Join(ignored, ", "))
+	}
+
+	for _, k := range []string{"key_id", "value"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PasswordCredentialExpiresInDays returns the number of whole days until a credential expires, or zero once it has
+func PasswordCredentialExpiresInDays(endDate time.Time) int {
+	remaining := time.Until(endDate)
+	if remaining < 0 {
+		return 0
+	}
+
+	return int(remaining / (24 * time.Hour))
+}
+
 type PasswordCredentialId struct {
 	ObjectId string
 	KeyId    string
@@ -106,6 +248,13 @@ func PasswordCredentialIdFrom(objectId, keyId string) PasswordCredentialId {
 
 func PasswordCredentialForResource(d *schema.ResourceData) (*graphrbac.PasswordCredential, error) {
 	value := d.Get("value").(string)
+	if value == "" {
+		generated, err := password.Generate(d.Get("value_length").(int), d.Get("value_characters").(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate `value`: %+v", err)
+		}
+		value = generated
+	}
 
 	// errors should be handled by the validation
 	var keyId string
@@ -145,9 +294,42 @@ func PasswordCredentialForResource(d *schema.ResourceData) (*graphrbac.PasswordC
 		credential.StartDate = &date.Time{Time: startDate}
 	}
 
+	if v, ok := d.GetOk("description"); ok {
+		customKeyIdentifier := PasswordCredentialCustomKeyIdentifier(v.(string))
+		credential.CustomKeyIdentifier = &customKeyIdentifier
+	}
+
 	return &credential, nil
 }
 
+// PasswordCredentialCustomKeyIdentifier returns a description encoded as UTF-16 (little endian) for the Custom Key
+// Identifier of a password, which is how the Azure Portal stores and displays it
+func PasswordCredentialCustomKeyIdentifier(description string) []byte {
+	runes := utf16.Encode([]rune(description))
+	b := make([]byte, 0, len(runes)*2)
+	for _, r := range runes {
+		b = append(b, byte(r), byte(r>>8))
+	}
+
+	return b
+}
+
+// PasswordCredentialDescription returns the description held in the Custom Key Identifier of a password, which is
+// encoded as UTF-16 (little endian) by both Terraform and the Azure Portal
+func PasswordCredentialDescription(credential graphrbac.PasswordCredential) string {
+	if credential.CustomKeyIdentifier == nil {
+		return ""
+	}
+
+	b := *credential.CustomKeyIdentifier
+	runes := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		runes = append(runes, uint16(b[i])|uint16(b[i+1])<<8)
+	}
+
+	return string(utf16.Decode(runes))
+}
+
 func PasswordCredentialResultFindByKeyId(creds graphrbac.PasswordCredentialListResult, keyId string) *graphrbac.PasswordCredential {
 	var cred *graphrbac.PasswordCredential
 
@@ -209,3 +391,242 @@ func PasswordCredentialResultRemoveByKeyId(existing graphrbac.PasswordCredential
 
 	return &newCreds
 }
+
+// valid types are `application` and `service_principal`
+func CertificateResourceSchema(object_type string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		object_type + "_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.UUID,
+		},
+
+		"key_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.UUID,
+		},
+
+		"encoding": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  "pem",
+			ValidateFunc: validation.StringInSlice([]string{
+				"pem",
+				"base64",
+			}, false),
+		},
+
+		"value": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.NoEmptyStrings,
+		},
+
+		"start_date": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.ValidateRFC3339TimeString,
+		},
+
+		"end_date": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"end_date_relative"},
+			ValidateFunc:  validation.ValidateRFC3339TimeString,
+		},
+
+		"end_date_relative": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"end_date"},
+			ValidateFunc:  validate.NoEmptyStrings,
+		},
+	}
+}
+
+type KeyCredentialId struct {
+	ObjectId string
+	KeyId    string
+}
+
+func (id KeyCredentialId) String() string {
+	return id.ObjectId + "/" + id.KeyId
+}
+
+func ParseKeyCredentialId(id string) (KeyCredentialId, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return KeyCredentialId{}, fmt.Errorf("Key Credential ID should be in the format {objectId}/{keyId} - but got %q", id)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return KeyCredentialId{}, fmt.Errorf("Object ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	if _, err := uuid.ParseUUID(parts[1]); err != nil {
+		return KeyCredentialId{}, fmt.Errorf("Key ID isn't a valid UUID (%q): %+v", parts[1], err)
+	}
+
+	return KeyCredentialId{
+		ObjectId: parts[0],
+		KeyId:    parts[1],
+	}, nil
+}
+
+func KeyCredentialIdFrom(objectId, keyId string) KeyCredentialId {
+	return KeyCredentialId{
+		ObjectId: objectId,
+		KeyId:    keyId,
+	}
+}
+
+// ParseCertificate decodes a PEM or base64 encoded DER certificate into an x509.Certificate
+func ParseCertificate(value, encoding string) (*x509.Certificate, error) {
+	var der []byte
+
+	switch encoding {
+	case "pem":
+		block, _ := pem.Decode([]byte(value))
+		if block == nil {
+			return nil, fmt.Errorf("unable to decode PEM certificate, no PEM data was found")
+		}
+		der = block.Bytes
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 certificate: %+v", err)
+		}
+		der = decoded
+	default:
+		return nil, fmt.Errorf("unsupported certificate encoding %q", encoding)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate: %+v", err)
+	}
+
+	return cert, nil
+}
+
+func KeyCredentialForResource(d *schema.ResourceData) (*graphrbac.KeyCredential, error) {
+	cert, err := ParseCertificate(d.Get("value").(string), d.Get("encoding").(string))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse `value`: %+v", err)
+	}
+
+	// errors should be handled by the validation
+	var keyId string
+	if v, ok := d.GetOk("key_id"); ok {
+		keyId = v.(string)
+	} else {
+		kid, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+
+		keyId = kid
+	}
+
+	// the dates default to the validity period of the certificate
+	startDate := cert.NotBefore
+	if v, ok := d.GetOk("start_date"); ok {
+		// errors will be handled by the validation
+		startDate, _ = time.Parse(time.RFC3339, v.(string))
+	}
+
+	endDate := cert.NotAfter
+	if v := d.Get("end_date").(string); v != "" {
+		endDate, _ = time.Parse(time.RFC3339, v)
+	} else if v := d.Get("end_date_relative").(string); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse `end_date_relative` (%s) as a duration", v)
+		}
+		endDate = time.Now().Add(d)
+	}
+
+	credential := graphrbac.KeyCredential{
+		KeyID:     p.String(keyId),
+		Type:      p.String("AsymmetricX509Cert"),
+		Usage:     p.String("Verify"),
+		Value:     p.String(base64.StdEncoding.EncodeToString(cert.Raw)),
+		StartDate: &date.Time{Time: startDate},
+		EndDate:   &date.Time{Time: endDate},
+	}
+
+	return &credential, nil
+}
+
+func KeyCredentialResultFindByKeyId(creds graphrbac.KeyCredentialListResult, keyId string) *graphrbac.KeyCredential {
+	var cred *graphrbac.KeyCredential
+
+	if creds.Value != nil {
+		for _, c := range *creds.Value {
+			if c.KeyID == nil {
+				continue
+			}
+
+			if *c.KeyID == keyId {
+				cred = &c
+				break
+			}
+		}
+	}
+
+	return cred
+}
+
+func KeyCredentialResultAdd(existing graphrbac.KeyCredentialListResult, cred *graphrbac.KeyCredential, errorOnDuplicate bool) (*[]graphrbac.KeyCredential, error) {
+	newCreds := make([]graphrbac.KeyCredential, 0)
+
+	if existing.Value != nil {
+		if errorOnDuplicate {
+			for _, v := range *existing.Value {
+				if v.KeyID == nil {
+					continue
+				}
+
+				if *v.KeyID == *cred.KeyID {
+					return nil, fmt.Errorf("credential already exists found")
+				}
+			}
+		}
+
+		newCreds = *existing.Value
+	}
+	newCreds = append(newCreds, *cred)
+
+	return &newCreds, nil
+}
+
+func KeyCredentialResultRemoveByKeyId(existing graphrbac.KeyCredentialListResult, keyId string) *[]graphrbac.KeyCredential {
+	newCreds := make([]graphrbac.KeyCredential, 0)
+
+	if existing.Value != nil {
+		for _, v := range *existing.Value {
+			if v.KeyID == nil {
+				continue
+			}
+
+			if *v.KeyID == keyId {
+				continue
+			}
+
+			newCreds = append(newCreds, v)
+		}
+	}
+
+	return &newCreds
+}
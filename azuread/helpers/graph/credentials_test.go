@@ -0,0 +1,223 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPasswordCredentialDescription(t *testing.T) {
+	cases := []struct {
+		Name                string
+		CustomKeyIdentifier *[]byte
+		Expected            string
+	}{
+		{
+			Name:     "Not Set",
+			Expected: "",
+		},
+		{
+			Name:                "ASCII",
+			CustomKeyIdentifier: &[]byte{'p', 0, 'o', 0, 'r', 0, 't', 0, 'a', 0, 'l', 0},
+			Expected:            "portal",
+		},
+		{
+			// these bytes are also valid UTF-8 ("ÀÁ"), but a description is always UTF-16
+			Name:                "Valid UTF-8",
+			CustomKeyIdentifier: &[]byte{0xc3, 0x80, 0xc3, 0x81},
+			Expected:            "\u80c3\u81c3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := PasswordCredentialDescription(graphrbac.PasswordCredential{CustomKeyIdentifier: tc.CustomKeyIdentifier})
+			if actual != tc.Expected {
+				t.Fatalf("Expected %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestPasswordCredentialCustomKeyIdentifier(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Description string
+		Expected    []byte
+	}{
+		{
+			Name:        "ASCII",
+			Description: "portal",
+			Expected:    []byte{'p', 0, 'o', 0, 'r', 0, 't', 0, 'a', 0, 'l', 0},
+		},
+		{
+			Name:        "Latin-1",
+			Description: "clé",
+			Expected:    []byte{'c', 0, 'l', 0, 0xe9, 0},
+		},
+		{
+			Name:        "CJK",
+			Description: "日本語",
+			Expected:    []byte{0xe5, 0x65, 0x2c, 0x67, 0x9e, 0x8a},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := PasswordCredentialCustomKeyIdentifier(tc.Description)
+			if !bytes.Equal(actual, tc.Expected) {
+				t.Fatalf("Expected %v but got %v", tc.Expected, actual)
+			}
+
+			// the description must be read back unchanged
+			if description := PasswordCredentialDescription(graphrbac.PasswordCredential{CustomKeyIdentifier: &actual}); description != tc.Description {
+				t.Fatalf("Expected %q to round trip but got %q", tc.Description, description)
+			}
+		})
+	}
+}
+
+func TestPasswordResourceIgnoredProperties(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   map[string]interface{}
+		Expected []string
+	}{
+		{
+			Name:     "Generated",
+			Config:   map[string]interface{}{"end_date_relative": "8760h", "description": "ci-pipeline"},
+			Expected: []string{},
+		},
+		{
+			Name:     "Value",
+			Config:   map[string]interface{}{"end_date_relative": "8760h", "value": "p@ssw0rd"},
+			Expected: []string{"value"},
+		},
+		{
+			Name:     "Key ID",
+			Config:   map[string]interface{}{"end_date_relative": "8760h", "key_id": "11111111-1111-1111-1111-111111111111"},
+			Expected: []string{"key_id"},
+		},
+		{
+			Name:     "Value Length",
+			Config:   map[string]interface{}{"end_date_relative": "8760h", "value_length": 40},
+			Expected: []string{"value_length"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Config["application_id"] = "00000000-0000-0000-0000-000000000000"
+			d := schema.TestResourceDataRaw(t, PasswordResourceSchema("application"), tc.Config)
+
+			if actual := PasswordResourceIgnoredProperties(d); !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("Expected %v but got %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestPasswordResourceSchema_imported(t *testing.T) {
+	r := &schema.Resource{
+		Schema:        PasswordResourceSchema("application"),
+		CustomizeDiff: PasswordResourceCustomizeDiff,
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"application_id":    "00000000-0000-0000-0000-000000000000",
+		"value":             "p@ssw0rd",
+		"end_date_relative": "8760h",
+	})
+	if err != nil {
+		t.Fatalf("Error building config: %+v", err)
+	}
+	c := terraform.NewResourceConfig(raw)
+
+	attributes := map[string]string{
+		"id":             "00000000-0000-0000-0000-000000000000/11111111-1111-1111-1111-111111111111",
+		"application_id": "00000000-0000-0000-0000-000000000000",
+		"key_id":         "11111111-1111-1111-1111-111111111111",
+		"start_date":     "2019-01-01T01:02:03Z",
+		"end_date":       "2099-01-01T01:02:03Z",
+	}
+
+	// an imported password has no value in the state
+	imported := &terraform.InstanceState{ID: attributes["id"], Attributes: attributes}
+	diff, err := r.Diff(imported, c, nil)
+	if err != nil {
+		t.Fatalf("Error diffing imported password: %+v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected an imported password not to be replaced, got: %+v", diff)
+	}
+
+	// whereas the value of a password created by Terraform is known, so changing it replaces the password
+	created := imported.DeepCopy()
+	created.Attributes["value"] = "0ld-p@ssw0rd"
+	created.Attributes["end_date_relative"] = "8760h"
+	diff, err = r.Diff(created, c, nil)
+	if err != nil {
+		t.Fatalf("Error diffing created password: %+v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatalf("Expected changing the value of a password to replace it, got: %+v", diff)
+	}
+}
+
+func TestPasswordResourceCustomizeDiffGenerated(t *testing.T) {
+	r := &schema.Resource{
+		Schema: PasswordResourceSchema("application"),
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			if err := PasswordResourceCustomizeDiffGenerated(d); err != nil {
+				return err
+			}
+			return PasswordResourceCustomizeDiff(d, meta)
+		},
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"application_id":    "00000000-0000-0000-0000-000000000000",
+		"key_id":            "11111111-1111-1111-1111-111111111111",
+		"value":             "p@ssw0rd",
+		"end_date_relative": "8760h",
+	})
+	if err != nil {
+		t.Fatalf("Error building config: %+v", err)
+	}
+	c := terraform.NewResourceConfig(raw)
+
+	// the configured key ID and value of a new password are ignored, since they're generated by the API
+	diff, err := r.Diff(nil, c, nil)
+	if err != nil {
+		t.Fatalf("Expected a configured value to be ignored rather than rejected, got: %+v", err)
+	}
+	for _, k := range []string{"key_id", "value"} {
+		if attr, ok := diff.Attributes[k]; !ok || !attr.NewComputed {
+			t.Fatalf("Expected %q of a new password to be computed, got: %+v", k, attr)
+		}
+	}
+
+	// so an existing password has another key ID and value than was configured, which mustn't replace it
+	attributes := map[string]string{
+		"id":                "00000000-0000-0000-0000-000000000000/22222222-2222-2222-2222-222222222222",
+		"application_id":    "00000000-0000-0000-0000-000000000000",
+		"key_id":            "22222222-2222-2222-2222-222222222222",
+		"value":             "generated",
+		"end_date_relative": "8760h",
+		"start_date":        "2019-01-01T01:02:03Z",
+		"end_date":          "2099-01-01T01:02:03Z",
+	}
+	created := &terraform.InstanceState{ID: attributes["id"], Attributes: attributes}
+	diff, err = r.Diff(created, c, nil)
+	if err != nil {
+		t.Fatalf("Error diffing created password: %+v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected a generated password not to be replaced, got: %+v", diff)
+	}
+}
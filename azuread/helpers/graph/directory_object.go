@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+)
+
+// DirectoryObjectUrl returns the URL used by the graph API to reference a directory object (user, group, service principal etc)
+func DirectoryObjectUrl(baseURI, tenantId, objectId string) string {
+	return fmt.Sprintf("%s/%s/directoryObjects/%s", strings.TrimSuffix(baseURI, "/"), tenantId, objectId)
+}
+
+// DirectoryObjectID returns the Object ID of a directory object regardless of its underlying type
+func DirectoryObjectID(obj graphrbac.BasicDirectoryObject) *string {
+	// AsDirectoryObject() returns nil for the concrete types, so each of them has to be checked in turn
+	if v, ok := obj.AsUser(); ok {
+		return v.ObjectID
+	}
+	if v, ok := obj.AsADGroup(); ok {
+		return v.ObjectID
+	}
+	if v, ok := obj.AsServicePrincipal(); ok {
+		return v.ObjectID
+	}
+	if v, ok := obj.AsApplication(); ok {
+		return v.ObjectID
+	}
+	if v, ok := obj.AsDirectoryObject(); ok {
+		return v.ObjectID
+	}
+
+	return nil
+}
+
+// DirectoryObjectListToIDs walks every page of the iterator and returns the Object IDs it contains
+func DirectoryObjectListToIDs(ctx context.Context, objects graphrbac.DirectoryObjectListResultIterator) ([]string, error) {
+	ids := make([]string, 0)
+	for objects.NotDone() {
+		if id := DirectoryObjectID(objects.Value()); id != nil {
+			ids = append(ids, *id)
+		}
+
+		if err := objects.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error during pagination of directory objects: %+v", err)
+		}
+	}
+
+	return ids, nil
+}
+
+// DirectoryObjectIdsReplicated returns whether the Object IDs include every ID in `present` and none in `absent`
+func DirectoryObjectIdsReplicated(ids []string, present, absent []string) bool {
+	found := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		found[strings.ToLower(id)] = true
+	}
+
+	for _, id := range present {
+		if !found[strings.ToLower(id)] {
+			return false
+		}
+	}
+
+	for _, id := range absent {
+		if found[strings.ToLower(id)] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,245 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-uuid"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+type GroupMemberId struct {
+	GroupId  string
+	MemberId string
+}
+
+func (id GroupMemberId) String() string {
+	return id.GroupId + "/" + id.MemberId
+}
+
+func ParseGroupMemberId(id string) (GroupMemberId, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return GroupMemberId{}, fmt.Errorf("Group Member ID should be in the format {groupObjectId}/{memberObjectId} - but got %q", id)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return GroupMemberId{}, fmt.Errorf("Group Object ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	if _, err := uuid.ParseUUID(parts[1]); err != nil {
+		return GroupMemberId{}, fmt.Errorf("Member Object ID isn't a valid UUID (%q): %+v", parts[1], err)
+	}
+
+	return GroupMemberId{
+		GroupId:  parts[0],
+		MemberId: parts[1],
+	}, nil
+}
+
+func GroupMemberIdFrom(groupId, memberId string) GroupMemberId {
+	return GroupMemberId{
+		GroupId:  groupId,
+		MemberId: memberId,
+	}
+}
+
+func GroupAddMember(client GroupsAPI, ctx context.Context, timeout time.Duration, groupId, memberId string) error {
+	properties := graphrbac.GroupAddMemberParameters{
+		URL: p.String(client.DirectoryObjectURL(memberId)),
+	}
+
+	// the group or the member may have only just been created
+	if err := RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		return client.AddMember(ctx, groupId, properties)
+	}); err != nil {
+		return fmt.Errorf("Error adding Member %q to Group %q: %+v", memberId, groupId, err)
+	}
+
+	return nil
+}
+
+func GroupAllMembers(client GroupsAPI, ctx context.Context, groupId string) ([]string, error) {
+	it, err := client.GetGroupMembersComplete(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Members of Group %q: %+v", groupId, err)
+	}
+
+	members, err := DirectoryObjectListToIDs(ctx, it)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Members of Group %q: %+v", groupId, err)
+	}
+
+	return members, nil
+}
+
+func GroupAddMembers(client GroupsAPI, ctx context.Context, timeout time.Duration, groupId string, members []string) error {
+	for _, memberId := range members {
+		if err := GroupAddMember(client, ctx, timeout, groupId, memberId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GroupRemoveMembers(client GroupsAPI, ctx context.Context, groupId string, members []string) error {
+	for _, memberId := range members {
+		if resp, err := client.RemoveMember(ctx, groupId, memberId); err != nil {
+			if !ar.ResponseWasNotFound(resp) {
+				return fmt.Errorf("Error removing Member %q from Group %q: %+v", memberId, groupId, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func GroupAllOwners(client GroupsAPI, ctx context.Context, groupId string) ([]string, error) {
+	it, err := client.ListOwnersComplete(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Owners of Group %q: %+v", groupId, err)
+	}
+
+	owners, err := DirectoryObjectListToIDs(ctx, it)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing existing Owners of Group %q: %+v", groupId, err)
+	}
+
+	return owners, nil
+}
+
+func GroupAddOwners(client GroupsAPI, ctx context.Context, timeout time.Duration, groupId string, owners []string) error {
+	for _, ownerId := range owners {
+		properties := graphrbac.AddOwnerParameters{
+			URL: p.String(client.DirectoryObjectURL(ownerId)),
+		}
+
+		// the group or the owner may have only just been created
+		if err := RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+			return client.AddOwner(ctx, groupId, properties)
+		}); err != nil {
+			return fmt.Errorf("Error adding Owner %q to Group %q: %+v", ownerId, groupId, err)
+		}
+	}
+
+	return nil
+}
+
+func GroupRemoveOwners(client GroupsAPI, ctx context.Context, groupId string, owners []string) error {
+	for _, ownerId := range owners {
+		if resp, err := client.RemoveOwner(ctx, groupId, ownerId); err != nil {
+			if !ar.ResponseWasNotFound(resp) {
+				return fmt.Errorf("Error removing Owner %q from Group %q: %+v", ownerId, groupId, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GroupWaitForMembers waits until the members of a group include every object in `present` and none in `absent`
+func GroupWaitForMembers(client GroupsAPI, ctx context.Context, timeout time.Duration, groupId string, present, absent []string) error {
+	if err := WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		members, err := GroupAllMembers(client, ctx, groupId)
+		return autorest.Response{}, err == nil && DirectoryObjectIdsReplicated(members, present, absent), err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for the Members of Group %q to replicate: %+v", groupId, err)
+	}
+
+	return nil
+}
+
+// GroupWaitForOwners waits until the owners of a group include every object in `present` and none in `absent`
+func GroupWaitForOwners(client GroupsAPI, ctx context.Context, timeout time.Duration, groupId string, present, absent []string) error {
+	if err := WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		owners, err := GroupAllOwners(client, ctx, groupId)
+		return autorest.Response{}, err == nil && DirectoryObjectIdsReplicated(owners, present, absent), err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for the Owners of Group %q to replicate: %+v", groupId, err)
+	}
+
+	return nil
+}
+
+func GroupIsMember(client GroupsAPI, ctx context.Context, groupId, memberId string) (bool, error) {
+	properties := graphrbac.CheckGroupMembershipParameters{
+		GroupID:  p.String(groupId),
+		MemberID: p.String(memberId),
+	}
+
+	resp, err := client.IsMemberOf(ctx, properties)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Value != nil && *resp.Value, nil
+}
+
+func GroupsListByFilter(client GroupsAPI, ctx context.Context, filter string) ([]graphrbac.ADGroup, error) {
+	it, err := client.ListComplete(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Groups with filter %q: %+v", filter, err)
+	}
+
+	groups := make([]graphrbac.ADGroup, 0)
+	for it.NotDone() {
+		groups = append(groups, it.Value())
+
+		if err := it.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Groups with filter %q: %+v", filter, err)
+		}
+	}
+
+	return groups, nil
+}
+
+// GroupFindByDisplayName returns the first Group with exactly the given display name, or nil when there is none
+func GroupFindByDisplayName(client GroupsAPI, ctx context.Context, displayName string) (*graphrbac.ADGroup, error) {
+	groups, err := GroupsListByFilter(client, ctx, fmt.Sprintf("displayName eq '%s'", ODataEscape(displayName)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		if g.DisplayName != nil && *g.DisplayName == displayName {
+			return &g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GroupGetByDisplayName returns the single Group with the given display name, names are not unique so an error is
+// returned when more than one Group matches
+func GroupGetByDisplayName(client GroupsAPI, ctx context.Context, displayName string) (*graphrbac.ADGroup, error) {
+	groups, err := GroupsListByFilter(client, ctx, fmt.Sprintf("displayName eq '%s'", ODataEscape(displayName)))
+	if err != nil {
+		return nil, err
+	}
+
+	var group *graphrbac.ADGroup
+	for _, g := range groups {
+		if g.DisplayName == nil || *g.DisplayName != displayName {
+			continue
+		}
+
+		if group != nil {
+			return nil, fmt.Errorf("Found multiple Groups with the name %q, use the Object ID to identify the Group", displayName)
+		}
+
+		g := g
+		group = &g
+	}
+
+	if group == nil {
+		return nil, fmt.Errorf("No Group found with the name %q", displayName)
+	}
+
+	return group, nil
+}
@@ -0,0 +1,288 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+const (
+	OAuth2PermissionGrantConsentTypeAllPrincipals = "AllPrincipals"
+	OAuth2PermissionGrantConsentTypePrincipal     = "Principal"
+)
+
+// OAuth2PermissionGrant pre-consents delegated permissions of a resource Service Principal on behalf of a client
+// Service Principal. The vendored graphrbac.Permissions model does not include the Object ID of a grant, which
+// is required to update or remove it.
+type OAuth2PermissionGrant struct {
+	autorest.Response `json:"-"`
+	// ObjectID - The ID of the grant itself
+	ObjectID *string `json:"objectId,omitempty"`
+	// ClientID - The Object ID of the client Service Principal
+	ClientID *string `json:"clientId,omitempty"`
+	// ConsentType - Possible values include: 'AllPrincipals', 'Principal'
+	ConsentType *string `json:"consentType,omitempty"`
+	// PrincipalID - The Object ID of the User on whose behalf consent is granted, only set when the consent type is 'Principal'
+	PrincipalID *string `json:"principalId,omitempty"`
+	// ResourceID - The Object ID of the resource Service Principal
+	ResourceID *string `json:"resourceId,omitempty"`
+	// Scope - A space separated list of the delegated permissions being granted
+	Scope *string `json:"scope,omitempty"`
+	// StartTime - Ignored by Azure Active Directory, but required when creating a grant
+	StartTime *string `json:"startTime,omitempty"`
+	// ExpiryTime - Ignored by Azure Active Directory, but required when creating a grant
+	ExpiryTime *string `json:"expiryTime,omitempty"`
+}
+
+// OAuth2PermissionGrantListResult is a single page of OAuth2 Permission Grants
+type OAuth2PermissionGrantListResult struct {
+	autorest.Response `json:"-"`
+	Value             *[]OAuth2PermissionGrant `json:"value,omitempty"`
+	OdataNextLink     *string                  `json:"odata.nextLink,omitempty"`
+}
+
+// OAuth2PermissionGrantsClient extends the vendored graphrbac.OAuth2Client with the operations needed to manage
+// the lifecycle of a grant
+type OAuth2PermissionGrantsClient struct {
+	graphrbac.OAuth2Client
+}
+
+func NewOAuth2PermissionGrantsClientWithBaseURI(baseURI string, tenantID string) OAuth2PermissionGrantsClient {
+	return OAuth2PermissionGrantsClient{graphrbac.NewOAuth2ClientWithBaseURI(baseURI, tenantID)}
+}
+
+// Create grants delegated permissions to a client Service Principal
+func (client OAuth2PermissionGrantsClient) Create(ctx context.Context, parameters OAuth2PermissionGrant) (result OAuth2PermissionGrant, err error) {
+	pathParameters := map[string]interface{}{
+		"tenantID": autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/oauth2PermissionGrants", pathParameters),
+		autorest.WithJSON(parameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "Create", nil, "Failure preparing request")
+	}
+
+	return client.respond(req, "Create", http.StatusOK, http.StatusCreated)
+}
+
+// Get retrieves a single grant by its Object ID, this shadows graphrbac.OAuth2Client#Get which cannot decode a list
+// of grants
+func (client OAuth2PermissionGrantsClient) Get(ctx context.Context, objectId string) (result OAuth2PermissionGrant, err error) {
+	pathParameters := map[string]interface{}{
+		"objectId": autorest.Encode("path", objectId),
+		"tenantID": autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/oauth2PermissionGrants/{objectId}", pathParameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "Get", nil, "Failure preparing request")
+	}
+
+	return client.respond(req, "Get", http.StatusOK)
+}
+
+// Update patches the scopes of an existing grant
+func (client OAuth2PermissionGrantsClient) Update(ctx context.Context, objectId string, parameters OAuth2PermissionGrant) (result autorest.Response, err error) {
+	pathParameters := map[string]interface{}{
+		"objectId": autorest.Encode("path", objectId),
+		"tenantID": autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPatch(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/oauth2PermissionGrants/{objectId}", pathParameters),
+		autorest.WithJSON(parameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "Update", nil, "Failure preparing request")
+	}
+
+	return client.respondWithoutBody(req, "Update")
+}
+
+// Delete removes a grant
+func (client OAuth2PermissionGrantsClient) Delete(ctx context.Context, objectId string) (result autorest.Response, err error) {
+	pathParameters := map[string]interface{}{
+		"objectId": autorest.Encode("path", objectId),
+		"tenantID": autorest.Encode("path", client.TenantID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsDelete(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/oauth2PermissionGrants/{objectId}", pathParameters),
+		autorest.WithQueryParameters(apiVersionQueryParameters()))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "Delete", nil, "Failure preparing request")
+	}
+
+	return client.respondWithoutBody(req, "Delete")
+}
+
+// ListComplete returns every grant matching the OData filter, crossing page boundaries as required
+func (client OAuth2PermissionGrantsClient) ListComplete(ctx context.Context, filter string) (result []OAuth2PermissionGrant, err error) {
+	pathParameters := map[string]interface{}{
+		"tenantID": autorest.Encode("path", client.TenantID),
+	}
+
+	queryParameters := apiVersionQueryParameters()
+	if filter != "" {
+		queryParameters["$filter"] = autorest.Encode("query", filter)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{tenantID}/oauth2PermissionGrants", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+
+	result = make([]OAuth2PermissionGrant, 0)
+	for {
+		req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+		if err != nil {
+			return nil, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "List", nil, "Failure preparing request")
+		}
+
+		var page OAuth2PermissionGrantListResult
+		resp, err := client.send(req)
+		if err != nil {
+			return nil, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "List", resp, "Failure sending request")
+		}
+
+		err = autorest.Respond(
+			resp,
+			client.ByInspecting(),
+			azure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&page),
+			autorest.ByClosing())
+		if err != nil {
+			return nil, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", "List", resp, "Failure responding to request")
+		}
+
+		if page.Value != nil {
+			result = append(result, *page.Value...)
+		}
+
+		if page.OdataNextLink == nil || *page.OdataNextLink == "" {
+			break
+		}
+
+		preparer = nextLinkPreparer(client.BaseURI, client.TenantID, *page.OdataNextLink)
+	}
+
+	return result, nil
+}
+
+func (client OAuth2PermissionGrantsClient) respond(req *http.Request, method string, codes ...int) (result OAuth2PermissionGrant, err error) {
+	resp, err := client.send(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", method, resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(codes...),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", method, resp, "Failure responding to request")
+	}
+
+	return result, err
+}
+
+func (client OAuth2PermissionGrantsClient) respondWithoutBody(req *http.Request, method string) (result autorest.Response, err error) {
+	resp, err := client.send(req)
+	if err != nil {
+		result.Response = resp
+		return result, autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", method, resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNoContent),
+		autorest.ByClosing())
+	result.Response = resp
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "graph.OAuth2PermissionGrantsClient", method, resp, "Failure responding to request")
+	}
+
+	return result, err
+}
+
+func (client OAuth2PermissionGrantsClient) send(req *http.Request) (*http.Response, error) {
+	return autorest.SendWithSender(client, req,
+		autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+// OAuth2PermissionGrantFind returns the grant of a client to a resource for the given consent type and principal
+func OAuth2PermissionGrantFind(client OAuth2PermissionGrantsAPI, ctx context.Context, clientId, resourceId, consentType, principalId string) (*OAuth2PermissionGrant, error) {
+	filter := fmt.Sprintf("clientId eq '%s'", clientId)
+	grants, err := client.ListComplete(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing OAuth2 Permission Grants for Service Principal %q: %+v", clientId, err)
+	}
+
+	for _, g := range grants {
+		if g.ResourceID == nil || !strings.EqualFold(*g.ResourceID, resourceId) {
+			continue
+		}
+		if g.ConsentType == nil || *g.ConsentType != consentType {
+			continue
+		}
+		if consentType == OAuth2PermissionGrantConsentTypePrincipal && (g.PrincipalID == nil || !strings.EqualFold(*g.PrincipalID, principalId)) {
+			continue
+		}
+		return &g, nil
+	}
+
+	return nil, nil
+}
+
+// OAuth2PermissionGrantScopesEqual returns whether two space separated lists of scopes hold the same scopes, as the
+// API may return them in another order or with other spacing than they were sent
+func OAuth2PermissionGrantScopesEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	scopes := make(map[string]bool)
+	for _, s := range strings.Fields(*a) {
+		scopes[s] = true
+	}
+
+	other := make(map[string]bool)
+	for _, s := range strings.Fields(*b) {
+		if !scopes[s] {
+			return false
+		}
+		other[s] = true
+	}
+
+	return len(scopes) == len(other)
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+func TestOAuth2PermissionGrantScopesEqual(t *testing.T) {
+	cases := []struct {
+		Name     string
+		A        *string
+		B        *string
+		Expected bool
+	}{
+		{
+			Name:     "Same",
+			A:        p.String("tasks_read user_impersonation"),
+			B:        p.String("tasks_read user_impersonation"),
+			Expected: true,
+		},
+		{
+			Name:     "Reordered",
+			A:        p.String("tasks_read user_impersonation"),
+			B:        p.String("user_impersonation tasks_read"),
+			Expected: true,
+		},
+		{
+			Name:     "Spacing",
+			A:        p.String("tasks_read user_impersonation"),
+			B:        p.String(" tasks_read  user_impersonation "),
+			Expected: true,
+		},
+		{
+			Name:     "Missing Scope",
+			A:        p.String("tasks_read user_impersonation"),
+			B:        p.String("user_impersonation"),
+			Expected: false,
+		},
+		{
+			Name:     "Additional Scope",
+			A:        p.String("user_impersonation"),
+			B:        p.String("tasks_read user_impersonation"),
+			Expected: false,
+		},
+		{
+			Name:     "Not Returned",
+			A:        p.String("user_impersonation"),
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := OAuth2PermissionGrantScopesEqual(tc.A, tc.B); actual != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+)
+
+// Azure Active Directory is eventually consistent, requests following a change can be served by a replica which
+// hasn't seen it yet. Objects can appear and disappear between consecutive reads until the change has replicated.
+
+const (
+	// replicationConsistentReads is how many consecutive reads have to see a change before it's considered replicated
+	replicationConsistentReads = 3
+
+	// replicationPollInterval is the time between reads once a change has first been seen
+	replicationPollInterval = 1 * time.Second
+)
+
+// ReplicationCheckFunc retrieves an object and reports whether the change being waited for is visible. The response
+// is used to tell an object which hasn't replicated yet apart from other errors.
+type ReplicationCheckFunc func() (resp autorest.Response, visible bool, err error)
+
+// WaitForReplication waits until the change reported by check has been visible for several consecutive reads, not found
+// responses are treated as the object not having replicated yet
+func WaitForReplication(timeout time.Duration, check ReplicationCheckFunc) error {
+	_, err := (&resource.StateChangeConf{
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Replicated"},
+		Timeout:                   timeout,
+		MinTimeout:                replicationPollInterval,
+		ContinuousTargetOccurence: replicationConsistentReads,
+		Refresh: func() (interface{}, string, error) {
+			resp, visible, err := check()
+			if err != nil {
+				if ar.ResponseWasNotFound(resp) {
+					return resp, "Waiting", nil
+				}
+				return nil, "", err
+			}
+
+			if !visible {
+				return resp, "Waiting", nil
+			}
+
+			return resp, "Replicated", nil
+		},
+	}).WaitForState()
+
+	return err
+}
+
+// WaitForCreation waits until a newly created object can consistently be retrieved
+func WaitForCreation(timeout time.Duration, get func() (autorest.Response, error)) error {
+	return WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		resp, err := get()
+		return resp, err == nil, err
+	})
+}
+
+// RetryOnReplicationDelay retries a request which references another object, such as creating the Service Principal for
+// a newly created Application, for as long as it fails because the referenced object hasn't replicated yet
+func RetryOnReplicationDelay(timeout time.Duration, f func() (autorest.Response, error)) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		resp, err := f()
+		if err != nil {
+			if ar.ResponseWasReplicationDelay(resp, err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+)
+
+// ServicePrincipalFindByAppId returns the Service Principal for the given Application ID, or nil when there is none
+func ServicePrincipalFindByAppId(client ServicePrincipalsAPI, ctx context.Context, appId string) (*graphrbac.ServicePrincipal, error) {
+	filter := fmt.Sprintf("appId eq '%s'", ODataEscape(appId))
+
+	it, err := client.ListComplete(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Service Principals with filter %q: %+v", filter, err)
+	}
+
+	for it.NotDone() {
+		if sp := it.Value(); sp.AppID != nil && *sp.AppID == appId {
+			return &sp, nil
+		}
+
+		if err := it.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Service Principals with filter %q: %+v", filter, err)
+		}
+	}
+
+	return nil, nil
+}
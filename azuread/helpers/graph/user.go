@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+)
+
+// the number of values combined into a single OData filter when looking up users in bulk, kept small to stay
+// within the limits the API places on the complexity of a filter
+const usersFilterBatchSize = 15
+
+// UsersListByFilter returns every user matching the OData filter, following the next link of each page
+func UsersListByFilter(client UsersAPI, ctx context.Context, filter string) ([]graphrbac.User, error) {
+	page, err := client.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Users with filter %q: %+v", filter, err)
+	}
+
+	users := make([]graphrbac.User, 0)
+	for page.NotDone() {
+		users = append(users, page.Values()...)
+
+		if err := page.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("Error listing Users with filter %q: %+v", filter, err)
+		}
+	}
+
+	return users, nil
+}
+
+// UsersFindByProperty looks up users whose `property` (e.g. `userPrincipalName`) matches any of the values, batching
+// the values into as few requests as possible. The results are keyed by the lower cased value of the property.
+func UsersFindByProperty(client UsersAPI, ctx context.Context, property string, values []string) (map[string]graphrbac.User, error) {
+	result := make(map[string]graphrbac.User)
+
+	for start := 0; start < len(values); start += usersFilterBatchSize {
+		end := start + usersFilterBatchSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		clauses := make([]string, 0, end-start)
+		for _, v := range values[start:end] {
+			clauses = append(clauses, fmt.Sprintf("%s eq '%s'", property, ODataEscape(v)))
+		}
+
+		users, err := UsersListByFilter(client, ctx, strings.Join(clauses, " or "))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range users {
+			if v := userProperty(u, property); v != nil {
+				result[strings.ToLower(*v)] = u
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// UserFindByPrincipalName returns the User with the given user principal name, or nil when there is none
+func UserFindByPrincipalName(client UsersAPI, ctx context.Context, userPrincipalName string) (*graphrbac.User, error) {
+	users, err := UsersFindByProperty(client, ctx, "userPrincipalName", []string{userPrincipalName})
+	if err != nil {
+		return nil, err
+	}
+
+	if u, ok := users[strings.ToLower(userPrincipalName)]; ok {
+		return &u, nil
+	}
+
+	return nil, nil
+}
+
+func userProperty(user graphrbac.User, property string) *string {
+	switch property {
+	case "objectId":
+		return user.ObjectID
+	case "userPrincipalName":
+		return user.UserPrincipalName
+	case "mailNickname":
+		return user.MailNickname
+	}
+
+	return nil
+}
+
+// UserProperties maps the schema keys of the optional string properties of a User to their names in the Graph API,
+// most of which aren't modelled by the SDK and so are sent and read as additional properties
+var UserProperties = map[string]string{
+	"city":            "city",
+	"company_name":    "companyName",
+	"country":         "country",
+	"department":      "department",
+	"given_name":      "givenName",
+	"immutable_id":    "immutableId",
+	"job_title":       "jobTitle",
+	"mobile_phone":    "mobile",
+	"office_location": "physicalDeliveryOfficeName",
+	"postal_code":     "postalCode",
+	"state":           "state",
+	"street_address":  "streetAddress",
+	"surname":         "surname",
+	"usage_location":  "usageLocation",
+	"user_type":       "userType",
+}
+
+// UserPropertyValues returns the values of the UserProperties and the other mail addresses of a User, keyed by their
+// schema key, regardless of whether they're modelled by the SDK
+func UserPropertyValues(user graphrbac.User) (map[string]string, []string, error) {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]string)
+	for key, property := range UserProperties {
+		v, _ := m[property].(string)
+		values[key] = v
+	}
+
+	otherMails := make([]string, 0)
+	if v, ok := m["otherMails"].([]interface{}); ok {
+		for _, mail := range v {
+			if s, ok := mail.(string); ok {
+				otherMails = append(otherMails, s)
+			}
+		}
+	}
+
+	return values, otherMails, nil
+}
+
+// ODataEscape escapes a value for use as a string literal within an OData filter
+func ODataEscape(value string) string {
+	return strings.Replace(value, "'", "''", -1)
+}
+
+// UserChangesReplicated returns whether the properties of an update, including the profile properties sent as
+// AdditionalProperties, are reflected by the user, the password is write only and so can't be checked
+func UserChangesReplicated(properties graphrbac.UserUpdateParameters, user graphrbac.User) bool {
+	if properties.DisplayName != nil && !stringPtrEqual(properties.DisplayName, user.DisplayName) {
+		return false
+	}
+	if properties.MailNickname != nil && !stringPtrEqual(properties.MailNickname, user.MailNickname) {
+		return false
+	}
+	if properties.AccountEnabled != nil && !boolPtrEqual(properties.AccountEnabled, user.AccountEnabled) {
+		return false
+	}
+
+	if len(properties.AdditionalProperties) == 0 {
+		return true
+	}
+
+	values, otherMails, err := UserPropertyValues(user)
+	if err != nil {
+		return false
+	}
+
+	// a null value clears the property, and values are compared regardless of case since the API normalises some of
+	// them (e.g. the country code of `usageLocation`), which would otherwise never be seen as replicated
+	for key, property := range UserProperties {
+		if v, ok := properties.AdditionalProperties[property]; ok {
+			expected, _ := v.(string)
+			if !strings.EqualFold(values[key], expected) {
+				return false
+			}
+		}
+	}
+
+	if v, ok := properties.AdditionalProperties["otherMails"].(*[]string); ok && !stringSlicesEquivalent(lowerStrings(stringSliceValue(v)), lowerStrings(otherMails)) {
+		return false
+	}
+
+	return true
+}
+
+func lowerStrings(input []string) []string {
+	result := make([]string, 0, len(input))
+	for _, v := range input {
+		result = append(result, strings.ToLower(v))
+	}
+	return result
+}
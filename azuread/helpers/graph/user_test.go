@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+func TestUserChangesReplicated(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Properties map[string]interface{}
+		User       graphrbac.User
+		Expected   bool
+	}{
+		{
+			Name:       "Modelled Property Replicated",
+			Properties: map[string]interface{}{"surname": "King"},
+			User:       graphrbac.User{Surname: p.String("King")},
+			Expected:   true,
+		},
+		{
+			Name:       "Modelled Property Stale",
+			Properties: map[string]interface{}{"surname": "King"},
+			User:       graphrbac.User{Surname: p.String("Lovelace")},
+			Expected:   false,
+		},
+		{
+			Name:       "Additional Property Replicated",
+			Properties: map[string]interface{}{"jobTitle": "Engineer"},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"jobTitle": "Engineer"}},
+			Expected:   true,
+		},
+		{
+			Name:       "Additional Property Stale",
+			Properties: map[string]interface{}{"jobTitle": "Engineer"},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"jobTitle": "Contractor"}},
+			Expected:   false,
+		},
+		{
+			Name:       "Additional Property Normalised",
+			Properties: map[string]interface{}{"usageLocation": "gb"},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"usageLocation": "GB"}},
+			Expected:   true,
+		},
+		{
+			Name:       "Property Cleared",
+			Properties: map[string]interface{}{"department": nil},
+			User:       graphrbac.User{},
+			Expected:   true,
+		},
+		{
+			Name:       "Property Not Yet Cleared",
+			Properties: map[string]interface{}{"department": nil},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"department": "Engineering"}},
+			Expected:   false,
+		},
+		{
+			Name:       "Other Mails Replicated",
+			Properties: map[string]interface{}{"otherMails": &[]string{"a@example.com"}},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"otherMails": []interface{}{"a@example.com"}}},
+			Expected:   true,
+		},
+		{
+			Name:       "Other Mails Normalised",
+			Properties: map[string]interface{}{"otherMails": &[]string{"A@Example.com"}},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"otherMails": []interface{}{"a@example.com"}}},
+			Expected:   true,
+		},
+		{
+			Name:       "Other Mails Stale",
+			Properties: map[string]interface{}{"otherMails": &[]string{}},
+			User:       graphrbac.User{AdditionalProperties: map[string]interface{}{"otherMails": []interface{}{"a@example.com"}}},
+			Expected:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			properties := graphrbac.UserUpdateParameters{AdditionalProperties: tc.Properties}
+			if actual := UserChangesReplicated(properties, tc.User); actual != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
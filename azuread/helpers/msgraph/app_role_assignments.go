@@ -0,0 +1,94 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// AppRoleAssignmentsClient is the Microsoft Graph implementation of graph.AppRoleAssignmentsAPI
+type AppRoleAssignmentsClient struct {
+	BaseClient
+}
+
+func NewAppRoleAssignmentsClientWithBaseURI(endpoint string) AppRoleAssignmentsClient {
+	return AppRoleAssignmentsClient{NewWithBaseURI(endpoint)}
+}
+
+const appRoleAssignmentsTypeName = "msgraph.AppRoleAssignmentsClient"
+
+// Create assigns an App Role to the specified principal
+func (client AppRoleAssignmentsClient) Create(ctx context.Context, principalType graphrbac.ObjectType, parameters graph.AppRoleAssignment) (result graph.AppRoleAssignment, err error) {
+	if parameters.PrincipalID == nil {
+		return result, fmt.Errorf("%s#Create: `PrincipalID` must be specified", appRoleAssignmentsTypeName)
+	}
+
+	collection, err := graph.PrincipalCollection(principalType)
+	if err != nil {
+		return result, err
+	}
+
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, appRoleAssignmentsTypeName, "Create", request{
+		method:     http.MethodPost,
+		path:       "/" + collection + "/{id}/appRoleAssignments",
+		parameters: map[string]interface{}{"id": *parameters.PrincipalID},
+		body:       appRoleAssignment.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(appRoleAssignment.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Delete removes an App Role Assignment from the specified principal
+func (client AppRoleAssignmentsClient) Delete(ctx context.Context, principalType graphrbac.ObjectType, principalId, assignmentId string) (autorest.Response, error) {
+	collection, err := graph.PrincipalCollection(principalType)
+	if err != nil {
+		return autorest.Response{}, err
+	}
+
+	return client.send(ctx, appRoleAssignmentsTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/" + collection + "/{id}/appRoleAssignments/{assignmentId}",
+		parameters: map[string]interface{}{"id": principalId, "assignmentId": assignmentId},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// ListComplete returns every App Role Assignment of the specified principal
+func (client AppRoleAssignmentsClient) ListComplete(ctx context.Context, principalType graphrbac.ObjectType, principalId string) (result []graph.AppRoleAssignment, err error) {
+	collection, err := graph.PrincipalCollection(principalType)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := client.pager(appRoleAssignmentsTypeName, request{
+		method:     http.MethodGet,
+		path:       "/" + collection + "/{id}/appRoleAssignments",
+		parameters: map[string]interface{}{"id": principalId},
+	}).all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list graph.AppRoleAssignmentListResult
+	if err := fromMap(translateList(values, entityOf(appRoleAssignment)), &list); err != nil {
+		return nil, err
+	}
+
+	result = make([]graph.AppRoleAssignment, 0)
+	if list.Value != nil {
+		result = append(result, *list.Value...)
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// ApplicationsClient is the Microsoft Graph implementation of graph.ApplicationsAPI
+type ApplicationsClient struct {
+	BaseClient
+}
+
+func NewApplicationsClientWithBaseURI(endpoint string) ApplicationsClient {
+	return ApplicationsClient{NewWithBaseURI(endpoint)}
+}
+
+const applicationsTypeName = "msgraph.ApplicationsClient"
+
+// Create creates a new Application
+func (client ApplicationsClient) Create(ctx context.Context, parameters graphrbac.ApplicationCreateParameters) (result graphrbac.Application, err error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, applicationsTypeName, "Create", request{
+		method: http.MethodPost,
+		path:   "/applications",
+		body:   application.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(application.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Get retrieves an Application by its Object ID
+func (client ApplicationsClient) Get(ctx context.Context, applicationObjectID string) (result graphrbac.Application, err error) {
+	m, resp, err := client.object(ctx, applicationsTypeName, "Get", request{
+		method:     http.MethodGet,
+		path:       "/applications/{id}",
+		parameters: map[string]interface{}{"id": applicationObjectID},
+	})
+	if err == nil {
+		err = fromMap(application.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Patch updates the specified properties of an Application
+func (client ApplicationsClient) Patch(ctx context.Context, applicationObjectID string, parameters graphrbac.ApplicationUpdateParameters) (autorest.Response, error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return autorest.Response{}, err
+	}
+
+	return client.send(ctx, applicationsTypeName, "Patch", request{
+		method:     http.MethodPatch,
+		path:       "/applications/{id}",
+		parameters: map[string]interface{}{"id": applicationObjectID},
+		body:       application.toMicrosoftGraph(body),
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// Delete deletes an Application
+func (client ApplicationsClient) Delete(ctx context.Context, applicationObjectID string) (autorest.Response, error) {
+	return client.send(ctx, applicationsTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/applications/{id}",
+		parameters: map[string]interface{}{"id": applicationObjectID},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// ListComplete enumerates the Applications matching the filter
+func (client ApplicationsClient) ListComplete(ctx context.Context, filter string) (graphrbac.ApplicationListResultIterator, error) {
+	p := client.pager(applicationsTypeName, request{
+		method: http.MethodGet,
+		path:   "/applications",
+		query:  filterQuery(filter),
+	})
+
+	page := graphrbac.NewApplicationListResultPage(func(ctx context.Context, _ graphrbac.ApplicationListResult) (result graphrbac.ApplicationListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, entityOf(application)), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	if err := page.NextWithContext(ctx); err != nil {
+		return graphrbac.ApplicationListResultIterator{}, err
+	}
+
+	return graphrbac.NewApplicationListResultIterator(page), nil
+}
+
+// AddOwner adds the directory object referenced by the URL of the parameters as an owner of the Application
+func (client ApplicationsClient) AddOwner(ctx context.Context, applicationObjectID string, parameters graphrbac.AddOwnerParameters) (autorest.Response, error) {
+	return client.addReference(ctx, applicationsTypeName, "AddOwner", "/applications/{id}/owners/$ref", applicationObjectID, parameters.URL)
+}
+
+// RemoveOwner removes an owner from the Application
+func (client ApplicationsClient) RemoveOwner(ctx context.Context, applicationObjectID string, ownerObjectID string) (autorest.Response, error) {
+	return client.removeReference(ctx, applicationsTypeName, "RemoveOwner", "/applications/{id}/owners/{referenceId}/$ref", applicationObjectID, ownerObjectID)
+}
+
+// ListOwnersComplete enumerates the owners of the Application
+func (client ApplicationsClient) ListOwnersComplete(ctx context.Context, applicationObjectID string) (graphrbac.DirectoryObjectListResultIterator, error) {
+	return client.listReferences(ctx, applicationsTypeName, "/applications/{id}/owners", applicationObjectID)
+}
+
+func (client ApplicationsClient) ListKeyCredentials(ctx context.Context, applicationObjectID string) (graphrbac.KeyCredentialListResult, error) {
+	return client.listKeyCredentials(ctx, applicationsTypeName, "applications", applicationObjectID)
+}
+
+func (client ApplicationsClient) UpdateKeyCredentials(ctx context.Context, applicationObjectID string, parameters graphrbac.KeyCredentialsUpdateParameters) (autorest.Response, error) {
+	return client.updateKeyCredentials(ctx, applicationsTypeName, "applications", applicationObjectID, parameters)
+}
+
+func (client ApplicationsClient) ListPasswordCredentials(ctx context.Context, applicationObjectID string) (graphrbac.PasswordCredentialListResult, error) {
+	return client.listPasswordCredentials(ctx, applicationsTypeName, "applications", applicationObjectID)
+}
+
+func (client ApplicationsClient) AddPasswordCredential(ctx context.Context, applicationObjectID string, credential graphrbac.PasswordCredential) (graph.AddedPasswordCredential, error) {
+	return client.addPassword(ctx, applicationsTypeName, "applications", applicationObjectID, credential)
+}
+
+func (client ApplicationsClient) UpdatePasswordCredentials(ctx context.Context, applicationObjectID string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error) {
+	return client.updatePasswordCredentials(ctx, applicationsTypeName, "applications", applicationObjectID, parameters)
+}
@@ -0,0 +1,260 @@
+// Package msgraph implements the client interfaces of the graph package using Microsoft Graph (graph.microsoft.com)
+// in place of the retired Azure AD Graph, translating requests and responses to and from the graphrbac models.
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// APIVersion is the version of Microsoft Graph used by the clients
+const APIVersion = "v1.0"
+
+// environmentEndpoints maps the go-autorest environments to their Microsoft Graph endpoints, which go-autorest
+// doesn't know about
+var environmentEndpoints = map[string]string{
+	azure.PublicCloud.Name:       "https://graph.microsoft.com/",
+	azure.ChinaCloud.Name:        "https://microsoftgraph.chinacloudapi.cn/",
+	azure.USGovernmentCloud.Name: "https://graph.microsoft.us/",
+	azure.GermanCloud.Name:       "https://graph.microsoft.de/",
+}
+
+// EnvironmentEndpoint returns the Microsoft Graph endpoint for an environment, which is also the resource tokens are
+// requested for
+func EnvironmentEndpoint(env azure.Environment) (string, error) {
+	endpoint, ok := environmentEndpoints[env.Name]
+	if !ok {
+		return "", fmt.Errorf("Microsoft Graph is not available in the environment %q", env.Name)
+	}
+
+	return endpoint, nil
+}
+
+var (
+	_ graph.ApplicationsAPI           = ApplicationsClient{}
+	_ graph.GroupsAPI                 = GroupsClient{}
+	_ graph.ServicePrincipalsAPI      = ServicePrincipalsClient{}
+	_ graph.UsersAPI                  = UsersClient{}
+	_ graph.ObjectsAPI                = ObjectsClient{}
+	_ graph.DomainsAPI                = DomainsClient{}
+	_ graph.AppRoleAssignmentsAPI     = AppRoleAssignmentsClient{}
+	_ graph.OAuth2PermissionGrantsAPI = OAuth2PermissionGrantsClient{}
+
+	_ graph.PasswordCredentialAdder = ApplicationsClient{}
+	_ graph.PasswordCredentialAdder = ServicePrincipalsClient{}
+)
+
+// BaseClient is the base client for Microsoft Graph
+type BaseClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+// NewWithBaseURI creates an instance of the BaseClient for the given endpoint, e.g. https://graph.microsoft.com/
+func NewWithBaseURI(endpoint string) BaseClient {
+	return BaseClient{
+		Client:  autorest.NewClientWithUserAgent(""),
+		BaseURI: strings.TrimSuffix(endpoint, "/") + "/" + APIVersion,
+	}
+}
+
+// DirectoryObjectURL returns the URL used to reference an object when adding it to a relationship
+func (client BaseClient) DirectoryObjectURL(objectId string) string {
+	return fmt.Sprintf("%s/directoryObjects/%s", client.BaseURI, objectId)
+}
+
+// request describes a single call to Microsoft Graph
+type request struct {
+	method string
+
+	// path is relative to the API version and may contain parameters, e.g. `/applications/{id}`
+	path       string
+	parameters map[string]interface{}
+	query      map[string]interface{}
+	body       interface{}
+
+	// nextLink is the absolute URL of the next page of a list, which is used in place of the path
+	nextLink string
+}
+
+// send performs the request, decoding the response into result when it isn't nil
+func (client BaseClient) send(ctx context.Context, typeName, method string, r request, codes []int, result interface{}) (autorest.Response, error) {
+	decorators := []autorest.PrepareDecorator{
+		autorest.WithMethod(r.method),
+	}
+
+	if r.nextLink != "" {
+		decorators = append(decorators, autorest.WithBaseURL(r.nextLink))
+	} else {
+		parameters := make(map[string]interface{})
+		for k, v := range r.parameters {
+			parameters[k] = autorest.Encode("path", v)
+		}
+
+		decorators = append(decorators,
+			autorest.WithBaseURL(client.BaseURI),
+			autorest.WithPathParameters(r.path, parameters))
+
+		if len(r.query) > 0 {
+			query := make(map[string]interface{})
+			for k, v := range r.query {
+				query[k] = autorest.Encode("query", v)
+			}
+			decorators = append(decorators, autorest.WithQueryParameters(query))
+		}
+	}
+
+	if r.body != nil {
+		decorators = append(decorators,
+			autorest.AsContentType("application/json; charset=utf-8"),
+			autorest.WithJSON(r.body))
+	}
+
+	req, err := autorest.CreatePreparer(decorators...).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return autorest.Response{}, autorest.NewErrorWithError(err, typeName, method, nil, "Failure preparing request")
+	}
+
+	resp, err := autorest.SendWithSender(client, req)
+	if err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, typeName, method, resp, "Failure sending request")
+	}
+
+	responders := []autorest.RespondDecorator{
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(codes...),
+	}
+	if result != nil {
+		responders = append(responders, autorest.ByUnmarshallingJSON(result))
+	}
+	responders = append(responders, autorest.ByClosing())
+
+	if err := autorest.Respond(resp, responders...); err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, typeName, method, resp, "Failure responding to request")
+	}
+
+	return autorest.Response{Response: resp}, nil
+}
+
+// object retrieves a single object
+func (client BaseClient) object(ctx context.Context, typeName, method string, r request) (map[string]interface{}, autorest.Response, error) {
+	m := make(map[string]interface{})
+	resp, err := client.send(ctx, typeName, method, r, []int{http.StatusOK, http.StatusCreated}, &m)
+	return m, resp, err
+}
+
+// listPage is a single page of a list
+type listPage struct {
+	Value    []map[string]interface{} `json:"value"`
+	NextLink string                   `json:"@odata.nextLink"`
+}
+
+// pager retrieves each page of a list in turn, following the `@odata.nextLink` of each page
+type pager struct {
+	client   BaseClient
+	typeName string
+	request  request
+	nextLink string
+	done     bool
+}
+
+func (client BaseClient) pager(typeName string, r request) *pager {
+	return &pager{
+		client:   client,
+		typeName: typeName,
+		request:  r,
+	}
+}
+
+// next returns the values of the next non-empty page, or no values once the list is exhausted
+func (p *pager) next(ctx context.Context) ([]map[string]interface{}, autorest.Response, error) {
+	for !p.done {
+		r := p.request
+		if p.nextLink != "" {
+			r = request{
+				method:   http.MethodGet,
+				nextLink: p.nextLink,
+			}
+		}
+
+		var page listPage
+		resp, err := p.client.send(ctx, p.typeName, "List", r, []int{http.StatusOK}, &page)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		p.nextLink = page.NextLink
+		p.done = page.NextLink == ""
+
+		if len(page.Value) > 0 {
+			return page.Value, resp, nil
+		}
+	}
+
+	return nil, autorest.Response{}, nil
+}
+
+// all returns the values of every page
+func (p *pager) all(ctx context.Context) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0)
+	for {
+		values, _, err := p.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return result, nil
+		}
+		result = append(result, values...)
+	}
+}
+
+// translateList returns the Azure AD Graph representation of a page of objects, in the shape of a graphrbac list result
+func translateList(values []map[string]interface{}, entityFor func(map[string]interface{}) entity) map[string]interface{} {
+	translated := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		translated = append(translated, entityFor(v).fromMicrosoftGraph(v))
+	}
+
+	return map[string]interface{}{
+		"value": translated,
+	}
+}
+
+func entityOf(e entity) func(map[string]interface{}) entity {
+	return func(map[string]interface{}) entity {
+		return e
+	}
+}
+
+func filterQuery(filter string) map[string]interface{} {
+	query := make(map[string]interface{})
+	if filter != "" {
+		query["$filter"] = filter
+	}
+	return query
+}
+
+// objectIdFromURL returns the Object ID from the URL of a directory object, as built by DirectoryObjectURL
+func objectIdFromURL(u *string) string {
+	if u == nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.TrimSuffix(*u, "/"), "/")
+	return segments[len(segments)-1]
+}
+
+// reference returns the body used to add an object to a relationship such as `members` or `owners`
+func (client BaseClient) reference(u *string) map[string]interface{} {
+	return map[string]interface{}{
+		"@odata.id": client.DirectoryObjectURL(objectIdFromURL(u)),
+	}
+}
@@ -0,0 +1,260 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+// testServer records the requests it receives and responds using the handler
+func testServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, body map[string]interface{})) (*httptest.Server, *[]string) {
+	requests := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.RequestURI()))
+
+		body := make(map[string]interface{})
+		if r.ContentLength > 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Error decoding the request body: %+v", err)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r, body)
+	}))
+
+	return server, &requests
+}
+
+func TestEnvironmentEndpoint(t *testing.T) {
+	endpoint, err := EnvironmentEndpoint(azure.PublicCloud)
+	if err != nil {
+		t.Fatalf("Expected an endpoint for the public cloud: %+v", err)
+	}
+	if endpoint != "https://graph.microsoft.com/" {
+		t.Fatalf("Expected the public cloud endpoint, got %q", endpoint)
+	}
+
+	if _, err := EnvironmentEndpoint(azure.Environment{Name: "unknown"}); err == nil {
+		t.Fatalf("Expected an error for an unknown environment")
+	}
+}
+
+func TestApplicationsClient_ListComplete(t *testing.T) {
+	var server *httptest.Server
+	server, requests := testServer(t, func(w http.ResponseWriter, r *http.Request, _ map[string]interface{}) {
+		page := listPage{}
+		switch r.URL.Query().Get("page") {
+		case "":
+			page.Value = []map[string]interface{}{{"id": "1", "displayName": "one"}}
+			page.NextLink = server.URL + "/v1.0/applications?page=2"
+		case "2":
+			// an empty page in the middle of a list shouldn't end it
+			page.NextLink = server.URL + "/v1.0/applications?page=3"
+		case "3":
+			page.Value = []map[string]interface{}{{"id": "2", "displayName": "two"}}
+		}
+		json.NewEncoder(w).Encode(page) // nolint: errcheck
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	iterator, err := NewApplicationsClientWithBaseURI(server.URL).ListComplete(ctx, "displayName eq 'one'")
+	if err != nil {
+		t.Fatalf("Error listing applications: %+v", err)
+	}
+
+	names := make([]string, 0)
+	for iterator.NotDone() {
+		app := iterator.Value()
+		names = append(names, *app.DisplayName)
+		if err := iterator.NextWithContext(ctx); err != nil {
+			t.Fatalf("Error listing applications: %+v", err)
+		}
+	}
+
+	if len(names) != 2 || names[0] != "one" || names[1] != "two" {
+		t.Fatalf("Expected both applications to be listed, got %v", names)
+	}
+	if (*requests)[0] != "GET /v1.0/applications?%24filter=displayName+eq+%27one%27" {
+		t.Fatalf("Expected the filter to be sent, got %q", (*requests)[0])
+	}
+}
+
+func TestGroupsClient_members(t *testing.T) {
+	server, requests := testServer(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.Method {
+		case http.MethodPost:
+			if r.URL.Path == "/v1.0/directoryObjects/member/checkMemberGroups" {
+				json.NewEncoder(w).Encode(map[string]interface{}{"value": body["groupIds"]}) // nolint: errcheck
+				return
+			}
+			if body["@odata.id"] == nil {
+				t.Errorf("Expected a reference to be sent, got %+v", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(listPage{Value: []map[string]interface{}{ // nolint: errcheck
+				{"@odata.type": "#microsoft.graph.user", "id": "member", "userPrincipalName": "member@example.com"},
+				{"@odata.type": "#microsoft.graph.servicePrincipal", "id": "sp", "appId": "app"},
+			}})
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewGroupsClientWithBaseURI(server.URL)
+
+	if _, err := client.AddMember(ctx, "group", graphrbac.GroupAddMemberParameters{URL: p.String(client.DirectoryObjectURL("member"))}); err != nil {
+		t.Fatalf("Error adding member: %+v", err)
+	}
+
+	result, err := client.IsMemberOf(ctx, graphrbac.CheckGroupMembershipParameters{GroupID: p.String("group"), MemberID: p.String("member")})
+	if err != nil {
+		t.Fatalf("Error checking membership: %+v", err)
+	}
+	if result.Value == nil || !*result.Value {
+		t.Fatalf("Expected the object to be a member")
+	}
+
+	iterator, err := client.GetGroupMembersComplete(ctx, "group")
+	if err != nil {
+		t.Fatalf("Error listing members: %+v", err)
+	}
+	if _, ok := iterator.Value().AsUser(); !ok {
+		t.Fatalf("Expected the first member to be a User, got %+v", iterator.Value())
+	}
+	if err := iterator.NextWithContext(ctx); err != nil {
+		t.Fatalf("Error listing members: %+v", err)
+	}
+	if _, ok := iterator.Value().AsServicePrincipal(); !ok {
+		t.Fatalf("Expected the second member to be a Service Principal, got %+v", iterator.Value())
+	}
+
+	if _, err := client.RemoveMember(ctx, "group", "member"); err != nil {
+		t.Fatalf("Error removing member: %+v", err)
+	}
+
+	expected := []string{
+		"POST /v1.0/groups/group/members/$ref",
+		"POST /v1.0/directoryObjects/member/checkMemberGroups",
+		"GET /v1.0/groups/group/members",
+		"DELETE /v1.0/groups/group/members/member/$ref",
+	}
+	for i, r := range expected {
+		if (*requests)[i] != r {
+			t.Errorf("Expected request %d to be %q, got %q", i, r, (*requests)[i])
+		}
+	}
+}
+
+func TestApplicationsClient_UpdatePasswordCredentials(t *testing.T) {
+	server, requests := testServer(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{ // nolint: errcheck
+				"passwordCredentials": []interface{}{
+					map[string]interface{}{"keyId": "keep"},
+					map[string]interface{}{"keyId": "remove"},
+				},
+			})
+		case http.MethodPost:
+			if body["keyId"] != "remove" {
+				t.Errorf("Expected only the absent password to be removed, got %+v", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewApplicationsClientWithBaseURI(server.URL)
+
+	if _, err := client.UpdatePasswordCredentials(ctx, "app", graphrbac.PasswordCredentialsUpdateParameters{
+		Value: &[]graphrbac.PasswordCredential{{KeyID: p.String("keep")}},
+	}); err != nil {
+		t.Fatalf("Error updating passwords: %+v", err)
+	}
+	if len(*requests) != 2 || (*requests)[1] != "POST /v1.0/applications/app/removePassword" {
+		t.Fatalf("Expected the password to be removed, got %v", *requests)
+	}
+
+	if _, err := client.UpdatePasswordCredentials(ctx, "app", graphrbac.PasswordCredentialsUpdateParameters{
+		Value: &[]graphrbac.PasswordCredential{{KeyID: p.String("keep")}, {KeyID: p.String("remove")}, {KeyID: p.String("new")}},
+	}); err == nil {
+		t.Fatalf("Expected an error adding a password")
+	}
+}
+
+func TestApplicationsClient_AddPasswordCredential(t *testing.T) {
+	server, requests := testServer(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.Method {
+		case http.MethodPost:
+			credential, _ := body["passwordCredential"].(map[string]interface{})
+			if credential["displayName"] != "ci-pipeline" || credential["endDateTime"] != "2099-01-01T00:00:00Z" {
+				t.Errorf("Expected the description and end date to be sent, got %+v", body)
+			}
+			if _, ok := credential["secretText"]; ok {
+				t.Errorf("Expected no value to be sent, got %+v", body)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{ // nolint: errcheck
+				"keyId":       "generated",
+				"displayName": "ci-pipeline",
+				"endDateTime": "2099-01-01T00:00:00Z",
+				"secretText":  "s3cret",
+			})
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{ // nolint: errcheck
+				"passwordCredentials": []interface{}{
+					map[string]interface{}{"keyId": "generated", "displayName": "ci-pipeline", "endDateTime": "2099-01-01T00:00:00Z"},
+				},
+			})
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewApplicationsClientWithBaseURI(server.URL)
+
+	description := graph.PasswordCredentialCustomKeyIdentifier("ci-pipeline")
+	added, err := client.AddPasswordCredential(ctx, "app", graphrbac.PasswordCredential{
+		KeyID:               p.String("ignored"),
+		Value:               p.String("ignored"),
+		EndDate:             &date.Time{Time: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)},
+		CustomKeyIdentifier: &description,
+	})
+	if err != nil {
+		t.Fatalf("Error adding password: %+v", err)
+	}
+	if len(*requests) != 1 || (*requests)[0] != "POST /v1.0/applications/app/addPassword" {
+		t.Fatalf("Expected the password to be added, got %v", *requests)
+	}
+	if added.KeyID == nil || *added.KeyID != "generated" || added.Value == nil || *added.Value != "s3cret" {
+		t.Fatalf("Expected the generated key ID and value to be returned, got %+v", added.PasswordCredential)
+	}
+
+	// the description is held in the display name, and must be read back the same as it was given
+	creds, err := client.ListPasswordCredentials(ctx, "app")
+	if err != nil {
+		t.Fatalf("Error listing passwords: %+v", err)
+	}
+	cred := graph.PasswordCredentialResultFindByKeyId(creds, "generated")
+	if cred == nil || graph.PasswordCredentialDescription(*cred) != "ci-pipeline" {
+		t.Fatalf("Expected the description to be read back, got %+v", creds.Value)
+	}
+	if description := graph.PasswordCredentialDescription(added.PasswordCredential); description != "ci-pipeline" {
+		t.Fatalf("Expected the description of the added password to be %q, got %q", "ci-pipeline", description)
+	}
+}
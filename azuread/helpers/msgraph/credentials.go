@@ -0,0 +1,168 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// credentials are managed the same way for Applications and Service Principals, which are distinguished by collection
+
+func (client BaseClient) listKeyCredentials(ctx context.Context, typeName, collection, objectId string) (result graphrbac.KeyCredentialListResult, err error) {
+	m, resp, err := client.object(ctx, typeName, "ListKeyCredentials", request{
+		method:     http.MethodGet,
+		path:       "/" + collection + "/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+		query:      map[string]interface{}{"$select": "keyCredentials"},
+	})
+	result.Response = resp
+	if err != nil {
+		return result, err
+	}
+
+	err = fromMap(map[string]interface{}{"value": each(fromMS(keyCredential))(m["keyCredentials"])}, &result)
+	result.Response = resp
+	return result, err
+}
+
+func (client BaseClient) updateKeyCredentials(ctx context.Context, typeName, collection, objectId string, parameters graphrbac.KeyCredentialsUpdateParameters) (autorest.Response, error) {
+	values := make([]interface{}, 0)
+	if parameters.Value != nil {
+		for _, c := range *parameters.Value {
+			m, err := toMap(c)
+			if err != nil {
+				return autorest.Response{}, err
+			}
+			values = append(values, keyCredential.toMicrosoftGraph(m))
+		}
+	}
+
+	return client.send(ctx, typeName, "UpdateKeyCredentials", request{
+		method:     http.MethodPatch,
+		path:       "/" + collection + "/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+		body:       map[string]interface{}{"keyCredentials": values},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+func (client BaseClient) listPasswordCredentials(ctx context.Context, typeName, collection, objectId string) (result graphrbac.PasswordCredentialListResult, err error) {
+	m, resp, err := client.object(ctx, typeName, "ListPasswordCredentials", request{
+		method:     http.MethodGet,
+		path:       "/" + collection + "/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+		query:      map[string]interface{}{"$select": "passwordCredentials"},
+	})
+	result.Response = resp
+	if err != nil {
+		return result, err
+	}
+
+	credentials := each(passwordDisplayNameAsDescription)(m["passwordCredentials"])
+	err = fromMap(map[string]interface{}{"value": each(fromMS(passwordCredential))(credentials)}, &result)
+	result.Response = resp
+	return result, err
+}
+
+// passwordDisplayNameAsDescription sets the Custom Key Identifier of a password added by Microsoft Graph to its display
+// name, where the description is held since the Custom Key Identifier can't be set, so that it's read back the same
+func passwordDisplayNameAsDescription(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok || m["customKeyIdentifier"] != nil {
+		return v
+	}
+
+	if displayName, ok := m["displayName"].(string); ok && displayName != "" {
+		m["customKeyIdentifier"] = base64.StdEncoding.EncodeToString(graph.PasswordCredentialCustomKeyIdentifier(displayName))
+	}
+	return m
+}
+
+// addPassword adds a password using the `addPassword` action, Microsoft Graph generates its key ID and value which are
+// returned. Only the dates and description of the credential are used.
+func (client BaseClient) addPassword(ctx context.Context, typeName, collection, objectId string, credential graphrbac.PasswordCredential) (result graph.AddedPasswordCredential, err error) {
+	body := make(map[string]interface{})
+	if credential.StartDate != nil {
+		body["startDateTime"] = credential.StartDate.Format(time.RFC3339)
+	}
+	if credential.EndDate != nil {
+		body["endDateTime"] = credential.EndDate.Format(time.RFC3339)
+	}
+	if description := graph.PasswordCredentialDescription(credential); description != "" {
+		body["displayName"] = description
+	}
+
+	m, resp, err := client.object(ctx, typeName, "AddPassword", request{
+		method:     http.MethodPost,
+		path:       "/" + collection + "/{id}/addPassword",
+		parameters: map[string]interface{}{"id": objectId},
+		body:       map[string]interface{}{"passwordCredential": body},
+	})
+	result.Response = resp
+	if err != nil {
+		return result, err
+	}
+
+	err = fromMap(fromMS(passwordCredential)(passwordDisplayNameAsDescription(m)), &result.PasswordCredential)
+	return result, err
+}
+
+// updatePasswordCredentials removes the passwords which are no longer present using the `removePassword` action, since
+// Microsoft Graph doesn't allow passwords to be replaced. Passwords can't be added this way, as Microsoft Graph generates
+// their values itself rather than accepting them, so are added using addPassword instead.
+func (client BaseClient) updatePasswordCredentials(ctx context.Context, typeName, collection, objectId string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error) {
+	existing, err := client.listPasswordCredentials(ctx, typeName, collection, objectId)
+	if err != nil {
+		return existing.Response, err
+	}
+
+	desired := make(map[string]bool)
+	if parameters.Value != nil {
+		for _, c := range *parameters.Value {
+			if c.KeyID != nil {
+				desired[strings.ToLower(*c.KeyID)] = true
+			}
+		}
+	}
+
+	current := make(map[string]bool)
+	if existing.Value != nil {
+		for _, c := range *existing.Value {
+			if c.KeyID != nil {
+				current[strings.ToLower(*c.KeyID)] = true
+			}
+		}
+	}
+
+	for keyId := range desired {
+		if !current[keyId] {
+			return autorest.Response{}, fmt.Errorf("%s#UpdatePasswordCredentials: cannot add password %q, Microsoft Graph generates the values of passwords and doesn't accept them - use AddPasswordCredential instead", typeName, keyId)
+		}
+	}
+
+	resp := existing.Response
+	for keyId := range current {
+		if desired[keyId] {
+			continue
+		}
+
+		resp, err = client.send(ctx, typeName, "UpdatePasswordCredentials", request{
+			method:     http.MethodPost,
+			path:       "/" + collection + "/{id}/removePassword",
+			parameters: map[string]interface{}{"id": objectId},
+			body:       map[string]interface{}{"keyId": keyId},
+		}, []int{http.StatusOK, http.StatusNoContent}, nil)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
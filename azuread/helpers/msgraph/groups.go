@@ -0,0 +1,152 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// GroupsClient is the Microsoft Graph implementation of graph.GroupsAPI
+type GroupsClient struct {
+	BaseClient
+}
+
+func NewGroupsClientWithBaseURI(endpoint string) GroupsClient {
+	return GroupsClient{NewWithBaseURI(endpoint)}
+}
+
+const groupsTypeName = "msgraph.GroupsClient"
+
+// Create creates a new Group
+func (client GroupsClient) Create(ctx context.Context, parameters graphrbac.GroupCreateParameters) (result graphrbac.ADGroup, err error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, groupsTypeName, "Create", request{
+		method: http.MethodPost,
+		path:   "/groups",
+		body:   group.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(group.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Get retrieves a Group by its Object ID
+func (client GroupsClient) Get(ctx context.Context, objectID string) (result graphrbac.ADGroup, err error) {
+	m, resp, err := client.object(ctx, groupsTypeName, "Get", request{
+		method:     http.MethodGet,
+		path:       "/groups/{id}",
+		parameters: map[string]interface{}{"id": objectID},
+	})
+	if err == nil {
+		err = fromMap(group.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Delete deletes a Group
+func (client GroupsClient) Delete(ctx context.Context, objectID string) (autorest.Response, error) {
+	return client.send(ctx, groupsTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/groups/{id}",
+		parameters: map[string]interface{}{"id": objectID},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// ListComplete enumerates the Groups matching the filter
+func (client GroupsClient) ListComplete(ctx context.Context, filter string) (graphrbac.GroupListResultIterator, error) {
+	p := client.pager(groupsTypeName, request{
+		method: http.MethodGet,
+		path:   "/groups",
+		query:  filterQuery(filter),
+	})
+
+	page := graphrbac.NewGroupListResultPage(func(ctx context.Context, _ graphrbac.GroupListResult) (result graphrbac.GroupListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, entityOf(group)), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	if err := page.NextWithContext(ctx); err != nil {
+		return graphrbac.GroupListResultIterator{}, err
+	}
+
+	return graphrbac.NewGroupListResultIterator(page), nil
+}
+
+// AddMember adds the directory object referenced by the URL of the parameters as a member of the Group
+func (client GroupsClient) AddMember(ctx context.Context, groupObjectID string, parameters graphrbac.GroupAddMemberParameters) (autorest.Response, error) {
+	return client.addReference(ctx, groupsTypeName, "AddMember", "/groups/{id}/members/$ref", groupObjectID, parameters.URL)
+}
+
+// RemoveMember removes a member from the Group
+func (client GroupsClient) RemoveMember(ctx context.Context, groupObjectID string, memberObjectID string) (autorest.Response, error) {
+	return client.removeReference(ctx, groupsTypeName, "RemoveMember", "/groups/{id}/members/{referenceId}/$ref", groupObjectID, memberObjectID)
+}
+
+// GetGroupMembersComplete enumerates the direct members of the Group
+func (client GroupsClient) GetGroupMembersComplete(ctx context.Context, objectID string) (graphrbac.DirectoryObjectListResultIterator, error) {
+	return client.listReferences(ctx, groupsTypeName, "/groups/{id}/members", objectID)
+}
+
+// AddOwner adds the directory object referenced by the URL of the parameters as an owner of the Group
+func (client GroupsClient) AddOwner(ctx context.Context, objectID string, parameters graphrbac.AddOwnerParameters) (autorest.Response, error) {
+	return client.addReference(ctx, groupsTypeName, "AddOwner", "/groups/{id}/owners/$ref", objectID, parameters.URL)
+}
+
+// RemoveOwner removes an owner from the Group
+func (client GroupsClient) RemoveOwner(ctx context.Context, objectID string, ownerObjectID string) (autorest.Response, error) {
+	return client.removeReference(ctx, groupsTypeName, "RemoveOwner", "/groups/{id}/owners/{referenceId}/$ref", objectID, ownerObjectID)
+}
+
+// ListOwnersComplete enumerates the owners of the Group
+func (client GroupsClient) ListOwnersComplete(ctx context.Context, objectID string) (graphrbac.DirectoryObjectListResultIterator, error) {
+	return client.listReferences(ctx, groupsTypeName, "/groups/{id}/owners", objectID)
+}
+
+// IsMemberOf checks whether an object is a member of the Group, either directly or transitively
+func (client GroupsClient) IsMemberOf(ctx context.Context, parameters graphrbac.CheckGroupMembershipParameters) (result graphrbac.CheckGroupMembershipResult, err error) {
+	var groupId, memberId string
+	if parameters.GroupID != nil {
+		groupId = *parameters.GroupID
+	}
+	if parameters.MemberID != nil {
+		memberId = *parameters.MemberID
+	}
+
+	var groups struct {
+		Value []string `json:"value"`
+	}
+	resp, err := client.send(ctx, groupsTypeName, "IsMemberOf", request{
+		method:     http.MethodPost,
+		path:       "/directoryObjects/{id}/checkMemberGroups",
+		parameters: map[string]interface{}{"id": memberId},
+		body:       map[string]interface{}{"groupIds": []string{groupId}},
+	}, []int{http.StatusOK}, &groups)
+	result.Response = resp
+	if err != nil {
+		return result, err
+	}
+
+	isMember := false
+	for _, id := range groups.Value {
+		if strings.EqualFold(id, groupId) {
+			isMember = true
+		}
+	}
+	result.Value = &isMember
+
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// OAuth2PermissionGrantsClient is the Microsoft Graph implementation of graph.OAuth2PermissionGrantsAPI
+type OAuth2PermissionGrantsClient struct {
+	BaseClient
+}
+
+func NewOAuth2PermissionGrantsClientWithBaseURI(endpoint string) OAuth2PermissionGrantsClient {
+	return OAuth2PermissionGrantsClient{NewWithBaseURI(endpoint)}
+}
+
+const oauth2PermissionGrantsTypeName = "msgraph.OAuth2PermissionGrantsClient"
+
+// Create grants delegated permissions to a client Service Principal
+func (client OAuth2PermissionGrantsClient) Create(ctx context.Context, parameters graph.OAuth2PermissionGrant) (result graph.OAuth2PermissionGrant, err error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, oauth2PermissionGrantsTypeName, "Create", request{
+		method: http.MethodPost,
+		path:   "/oauth2PermissionGrants",
+		body:   oauth2PermissionGrant.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(oauth2PermissionGrant.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Get retrieves a grant by its Object ID
+func (client OAuth2PermissionGrantsClient) Get(ctx context.Context, objectId string) (result graph.OAuth2PermissionGrant, err error) {
+	m, resp, err := client.object(ctx, oauth2PermissionGrantsTypeName, "Get", request{
+		method:     http.MethodGet,
+		path:       "/oauth2PermissionGrants/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+	})
+	if err == nil {
+		err = fromMap(oauth2PermissionGrant.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Update updates the scope of a grant
+func (client OAuth2PermissionGrantsClient) Update(ctx context.Context, objectId string, parameters graph.OAuth2PermissionGrant) (autorest.Response, error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return autorest.Response{}, err
+	}
+
+	return client.send(ctx, oauth2PermissionGrantsTypeName, "Update", request{
+		method:     http.MethodPatch,
+		path:       "/oauth2PermissionGrants/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+		body:       oauth2PermissionGrant.toMicrosoftGraph(body),
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// Delete removes a grant
+func (client OAuth2PermissionGrantsClient) Delete(ctx context.Context, objectId string) (autorest.Response, error) {
+	return client.send(ctx, oauth2PermissionGrantsTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/oauth2PermissionGrants/{id}",
+		parameters: map[string]interface{}{"id": objectId},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// ListComplete returns every grant matching the filter
+func (client OAuth2PermissionGrantsClient) ListComplete(ctx context.Context, filter string) (result []graph.OAuth2PermissionGrant, err error) {
+	values, err := client.pager(oauth2PermissionGrantsTypeName, request{
+		method: http.MethodGet,
+		path:   "/oauth2PermissionGrants",
+		query:  filterQuery(filter),
+	}).all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list graph.OAuth2PermissionGrantListResult
+	if err := fromMap(translateList(values, entityOf(oauth2PermissionGrant)), &list); err != nil {
+		return nil, err
+	}
+
+	result = make([]graph.OAuth2PermissionGrant, 0)
+	if list.Value != nil {
+		result = append(result, *list.Value...)
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// ObjectsClient is the Microsoft Graph implementation of graph.ObjectsAPI
+type ObjectsClient struct {
+	BaseClient
+}
+
+func NewObjectsClientWithBaseURI(endpoint string) ObjectsClient {
+	return ObjectsClient{NewWithBaseURI(endpoint)}
+}
+
+// GetObjectsByObjectIds retrieves the directory objects with the given Object IDs, optionally limited to some types
+func (client ObjectsClient) GetObjectsByObjectIds(ctx context.Context, parameters graphrbac.GetObjectsParameters) (graphrbac.DirectoryObjectListResultPage, error) {
+	body := map[string]interface{}{
+		"ids": []string{},
+	}
+	if parameters.ObjectIds != nil {
+		body["ids"] = *parameters.ObjectIds
+	}
+	if parameters.Types != nil && len(*parameters.Types) > 0 {
+		// Azure AD Graph object types are capitalised, e.g. `ServicePrincipal` rather than `servicePrincipal`
+		types := make([]string, 0, len(*parameters.Types))
+		for _, t := range *parameters.Types {
+			if t != "" {
+				types = append(types, strings.ToLower(t[:1])+t[1:])
+			}
+		}
+		body["types"] = types
+	}
+
+	p := client.pager("msgraph.ObjectsClient", request{
+		method: http.MethodPost,
+		path:   "/directoryObjects/getByIds",
+		body:   body,
+	})
+
+	page := graphrbac.NewDirectoryObjectListResultPage(func(ctx context.Context, _ graphrbac.DirectoryObjectListResult) (result graphrbac.DirectoryObjectListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, directoryObjectEntity), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	return page, page.NextWithContext(ctx)
+}
+
+// DomainsClient is the Microsoft Graph implementation of graph.DomainsAPI
+type DomainsClient struct {
+	BaseClient
+}
+
+func NewDomainsClientWithBaseURI(endpoint string) DomainsClient {
+	return DomainsClient{NewWithBaseURI(endpoint)}
+}
+
+// List returns the domains of the tenant
+func (client DomainsClient) List(ctx context.Context, filter string) (result graphrbac.DomainListResult, err error) {
+	values, err := client.pager("msgraph.DomainsClient", request{
+		method: http.MethodGet,
+		path:   "/domains",
+		query:  filterQuery(filter),
+	}).all(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	err = fromMap(translateList(values, entityOf(domain)), &result)
+	return result, err
+}
+
+// listReferences returns the objects in a relationship such as `members` or `owners`
+func (client BaseClient) listReferences(ctx context.Context, typeName, path, objectId string) (graphrbac.DirectoryObjectListResultIterator, error) {
+	p := client.pager(typeName, request{
+		method:     http.MethodGet,
+		path:       path,
+		parameters: map[string]interface{}{"id": objectId},
+	})
+
+	return directoryObjectIterator(ctx, p)
+}
+
+func (client BaseClient) addReference(ctx context.Context, typeName, method, path, objectId string, u *string) (autorest.Response, error) {
+	return client.send(ctx, typeName, method, request{
+		method:     http.MethodPost,
+		path:       path,
+		parameters: map[string]interface{}{"id": objectId},
+		body:       client.reference(u),
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+func (client BaseClient) removeReference(ctx context.Context, typeName, method, path, objectId, referenceId string) (autorest.Response, error) {
+	return client.send(ctx, typeName, method, request{
+		method:     http.MethodDelete,
+		path:       path,
+		parameters: map[string]interface{}{"id": objectId, "referenceId": referenceId},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+func directoryObjectIterator(ctx context.Context, p *pager) (graphrbac.DirectoryObjectListResultIterator, error) {
+	page := graphrbac.NewDirectoryObjectListResultPage(func(ctx context.Context, _ graphrbac.DirectoryObjectListResult) (result graphrbac.DirectoryObjectListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, directoryObjectEntity), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	if err := page.NextWithContext(ctx); err != nil {
+		return graphrbac.DirectoryObjectListResultIterator{}, err
+	}
+
+	return graphrbac.NewDirectoryObjectListResultIterator(page), nil
+}
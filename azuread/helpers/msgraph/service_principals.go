@@ -0,0 +1,108 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// ServicePrincipalsClient is the Microsoft Graph implementation of graph.ServicePrincipalsAPI
+type ServicePrincipalsClient struct {
+	BaseClient
+}
+
+func NewServicePrincipalsClientWithBaseURI(endpoint string) ServicePrincipalsClient {
+	return ServicePrincipalsClient{NewWithBaseURI(endpoint)}
+}
+
+const servicePrincipalsTypeName = "msgraph.ServicePrincipalsClient"
+
+// Create creates a new Service Principal for an Application
+func (client ServicePrincipalsClient) Create(ctx context.Context, parameters graphrbac.ServicePrincipalCreateParameters) (result graphrbac.ServicePrincipal, err error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, servicePrincipalsTypeName, "Create", request{
+		method: http.MethodPost,
+		path:   "/servicePrincipals",
+		body:   servicePrincipal.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(servicePrincipal.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Get retrieves a Service Principal by its Object ID
+func (client ServicePrincipalsClient) Get(ctx context.Context, objectID string) (result graphrbac.ServicePrincipal, err error) {
+	m, resp, err := client.object(ctx, servicePrincipalsTypeName, "Get", request{
+		method:     http.MethodGet,
+		path:       "/servicePrincipals/{id}",
+		parameters: map[string]interface{}{"id": objectID},
+	})
+	if err == nil {
+		err = fromMap(servicePrincipal.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Delete deletes a Service Principal
+func (client ServicePrincipalsClient) Delete(ctx context.Context, objectID string) (autorest.Response, error) {
+	return client.send(ctx, servicePrincipalsTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/servicePrincipals/{id}",
+		parameters: map[string]interface{}{"id": objectID},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// ListComplete enumerates the Service Principals matching the filter
+func (client ServicePrincipalsClient) ListComplete(ctx context.Context, filter string) (graphrbac.ServicePrincipalListResultIterator, error) {
+	p := client.pager(servicePrincipalsTypeName, request{
+		method: http.MethodGet,
+		path:   "/servicePrincipals",
+		query:  filterQuery(filter),
+	})
+
+	page := graphrbac.NewServicePrincipalListResultPage(func(ctx context.Context, _ graphrbac.ServicePrincipalListResult) (result graphrbac.ServicePrincipalListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, entityOf(servicePrincipal)), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	if err := page.NextWithContext(ctx); err != nil {
+		return graphrbac.ServicePrincipalListResultIterator{}, err
+	}
+
+	return graphrbac.NewServicePrincipalListResultIterator(page), nil
+}
+
+func (client ServicePrincipalsClient) ListKeyCredentials(ctx context.Context, objectID string) (graphrbac.KeyCredentialListResult, error) {
+	return client.listKeyCredentials(ctx, servicePrincipalsTypeName, "servicePrincipals", objectID)
+}
+
+func (client ServicePrincipalsClient) UpdateKeyCredentials(ctx context.Context, objectID string, parameters graphrbac.KeyCredentialsUpdateParameters) (autorest.Response, error) {
+	return client.updateKeyCredentials(ctx, servicePrincipalsTypeName, "servicePrincipals", objectID, parameters)
+}
+
+func (client ServicePrincipalsClient) ListPasswordCredentials(ctx context.Context, objectID string) (graphrbac.PasswordCredentialListResult, error) {
+	return client.listPasswordCredentials(ctx, servicePrincipalsTypeName, "servicePrincipals", objectID)
+}
+
+func (client ServicePrincipalsClient) AddPasswordCredential(ctx context.Context, objectID string, credential graphrbac.PasswordCredential) (graph.AddedPasswordCredential, error) {
+	return client.addPassword(ctx, servicePrincipalsTypeName, "servicePrincipals", objectID, credential)
+}
+
+func (client ServicePrincipalsClient) UpdatePasswordCredentials(ctx context.Context, objectID string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error) {
+	return client.updatePasswordCredentials(ctx, servicePrincipalsTypeName, "servicePrincipals", objectID, parameters)
+}
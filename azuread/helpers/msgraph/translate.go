@@ -0,0 +1,373 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Microsoft Graph represents most directory objects differently to Azure AD Graph: properties are renamed, grouped
+// into nested objects or have different types. The clients in this package translate between the two at the JSON level,
+// so that the graphrbac models can continue to be used by the provider regardless of the API in use.
+
+// entity describes how the properties of a type are translated
+type entity struct {
+	// objectType is the value of the Azure AD Graph `objectType` discriminator for directory objects
+	objectType string
+
+	properties []property
+
+	// ignored lists Azure AD Graph properties which have no Microsoft Graph equivalent and are not sent
+	ignored []string
+}
+
+// property maps an Azure AD Graph property to its Microsoft Graph equivalent, which may be nested (e.g. `web.homePageUrl`).
+// Properties of Microsoft Graph objects which aren't listed are dropped, since some share the name of an Azure AD Graph
+// property with a different type.
+type property struct {
+	aad string
+	ms  string
+
+	// toMS and fromMS optionally convert the value, when the representation differs
+	toMS   func(interface{}) interface{}
+	fromMS func(interface{}) interface{}
+}
+
+func same(names ...string) []property {
+	properties := make([]property, 0, len(names))
+	for _, name := range names {
+		properties = append(properties, property{aad: name, ms: name})
+	}
+	return properties
+}
+
+func join(properties ...[]property) []property {
+	result := make([]property, 0)
+	for _, p := range properties {
+		result = append(result, p...)
+	}
+	return result
+}
+
+// fromMicrosoftGraph returns the Azure AD Graph representation of a Microsoft Graph object
+func (e entity) fromMicrosoftGraph(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+
+	for _, p := range e.properties {
+		v, ok := getPath(in, p.ms)
+		if !ok || v == nil {
+			continue
+		}
+
+		if p.fromMS != nil {
+			v = p.fromMS(v)
+		}
+		out[p.aad] = v
+	}
+
+	if e.objectType != "" {
+		out["objectType"] = e.objectType
+	}
+
+	return out
+}
+
+// toMicrosoftGraph returns the Microsoft Graph representation of an Azure AD Graph object, properties which aren't
+// mapped are sent as-is so that the API rejects anything unsupported rather than it being silently dropped
+func (e entity) toMicrosoftGraph(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+
+	for k, v := range in {
+		if k == "objectType" || k == "odata.type" || e.isIgnored(k) {
+			continue
+		}
+
+		p := e.property(k)
+		if p == nil {
+			out[k] = v
+			continue
+		}
+
+		if p.toMS != nil && v != nil {
+			v = p.toMS(v)
+		}
+		setPath(out, p.ms, v)
+	}
+
+	return out
+}
+
+func (e entity) property(aad string) *property {
+	for _, p := range e.properties {
+		if p.aad == aad {
+			return &p
+		}
+	}
+	return nil
+}
+
+func (e entity) isIgnored(aad string) bool {
+	for _, name := range e.ignored {
+		if name == aad {
+			return true
+		}
+	}
+	return false
+}
+
+// each returns a conversion which translates every element of a list
+func each(convert func(interface{}) interface{}) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		list, ok := v.([]interface{})
+		if !ok {
+			return v
+		}
+
+		result := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			result = append(result, convert(item))
+		}
+		return result
+	}
+}
+
+func toMS(e entity) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		if m, ok := v.(map[string]interface{}); ok {
+			return e.toMicrosoftGraph(m)
+		}
+		return v
+	}
+}
+
+func fromMS(e entity) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		if m, ok := v.(map[string]interface{}); ok {
+			return e.fromMicrosoftGraph(m)
+		}
+		return v
+	}
+}
+
+func getPath(m map[string]interface{}, path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		v, ok := m[segment]
+		if !ok {
+			return nil, false
+		}
+		if i == len(segments)-1 {
+			return v, true
+		}
+		if m, ok = v.(map[string]interface{}); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func setPath(m map[string]interface{}, path string, v interface{}) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := m[segment].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[segment] = next
+		}
+		m = next
+	}
+	m[segments[len(segments)-1]] = v
+}
+
+// toMap returns the JSON representation of a graphrbac model
+func toMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fromMap populates a graphrbac model from its JSON representation
+func fromMap(m interface{}, v interface{}) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+var keyCredential = entity{
+	properties: join(
+		same("customKeyIdentifier", "keyId", "type", "usage"),
+		[]property{
+			{aad: "endDate", ms: "endDateTime"},
+			{aad: "startDate", ms: "startDateTime"},
+			{aad: "value", ms: "key"},
+		},
+	),
+}
+
+var passwordCredential = entity{
+	properties: join(
+		same("customKeyIdentifier", "keyId"),
+		[]property{
+			{aad: "endDate", ms: "endDateTime"},
+			{aad: "startDate", ms: "startDateTime"},
+			{aad: "value", ms: "secretText"},
+		},
+	),
+}
+
+var credentials = []property{
+	{aad: "keyCredentials", ms: "keyCredentials", toMS: each(toMS(keyCredential)), fromMS: each(fromMS(keyCredential))},
+	{aad: "passwordCredentials", ms: "passwordCredentials", toMS: each(toMS(passwordCredential)), fromMS: each(fromMS(passwordCredential))},
+}
+
+const (
+	signInAudienceMyOrg        = "AzureADMyOrg"
+	signInAudienceMultipleOrgs = "AzureADMultipleOrgs"
+)
+
+var application = entity{
+	objectType: "Application",
+	properties: join(
+		same("appId", "appRoles", "displayName", "groupMembershipClaims", "identifierUris", "requiredResourceAccess"),
+		credentials,
+		[]property{
+			{aad: "objectId", ms: "id"},
+			{aad: "homepage", ms: "web.homePageUrl"},
+			{aad: "logoutUrl", ms: "web.logoutUrl"},
+			{aad: "replyUrls", ms: "web.redirectUris"},
+			{aad: "oauth2AllowImplicitFlow", ms: "web.implicitGrantSettings.enableAccessTokenIssuance"},
+			{aad: "oauth2Permissions", ms: "api.oauth2PermissionScopes"},
+			{aad: "knownClientApplications", ms: "api.knownClientApplications"},
+			{aad: "publicClient", ms: "isFallbackPublicClient"},
+			{
+				aad: "availableToOtherTenants",
+				ms:  "signInAudience",
+				toMS: func(v interface{}) interface{} {
+					if b, ok := v.(bool); ok && b {
+						return signInAudienceMultipleOrgs
+					}
+					return signInAudienceMyOrg
+				},
+				fromMS: func(v interface{}) interface{} {
+					return v != signInAudienceMyOrg
+				},
+			},
+		},
+	),
+}
+
+var servicePrincipal = entity{
+	objectType: "ServicePrincipal",
+	properties: join(
+		same("accountEnabled", "appDisplayName", "appId", "appOwnerTenantId", "appRoleAssignmentRequired", "appRoles", "displayName", "homepage", "logoutUrl", "replyUrls", "servicePrincipalNames", "servicePrincipalType", "tags"),
+		credentials,
+		[]property{
+			{aad: "objectId", ms: "id"},
+			{aad: "oauth2Permissions", ms: "oauth2PermissionScopes"},
+		},
+	),
+}
+
+var group = entity{
+	objectType: "Group",
+	properties: join(
+		same("description", "displayName", "mail", "mailEnabled", "mailNickname", "securityEnabled"),
+		[]property{
+			{aad: "objectId", ms: "id"},
+		},
+	),
+}
+
+var passwordProfile = entity{
+	properties: join(
+		same("password"),
+		[]property{
+			{aad: "forceChangePasswordNextLogin", ms: "forceChangePasswordNextSignIn"},
+		},
+	),
+}
+
+// userProperties are the properties of a User which need to be explicitly selected, since Microsoft Graph only
+// returns a handful by default
+var userProperties = []string{
+	"accountEnabled", "city", "companyName", "country", "department", "displayName", "givenName", "id", "jobTitle",
+	"mail", "mailNickname", "mobilePhone", "officeLocation", "onPremisesImmutableId", "otherMails", "postalCode",
+	"state", "streetAddress", "surname", "usageLocation", "userPrincipalName", "userType",
+}
+
+var user = entity{
+	objectType: "User",
+	properties: join(
+		same("accountEnabled", "city", "companyName", "country", "department", "displayName", "givenName", "jobTitle",
+			"mail", "mailNickname", "otherMails", "postalCode", "state", "streetAddress", "surname", "usageLocation",
+			"userPrincipalName", "userType"),
+		[]property{
+			{aad: "objectId", ms: "id"},
+			{aad: "immutableId", ms: "onPremisesImmutableId"},
+			{aad: "mobile", ms: "mobilePhone"},
+			{aad: "physicalDeliveryOfficeName", ms: "officeLocation"},
+			{aad: "passwordProfile", ms: "passwordProfile", toMS: toMS(passwordProfile), fromMS: fromMS(passwordProfile)},
+		},
+	),
+}
+
+var directoryObject = entity{
+	objectType: "DirectoryObject",
+	properties: []property{
+		{aad: "objectId", ms: "id"},
+	},
+}
+
+// directoryObjectEntity returns the entity for a directory object based on its `@odata.type`
+func directoryObjectEntity(m map[string]interface{}) entity {
+	switch m["@odata.type"] {
+	case "#microsoft.graph.application":
+		return application
+	case "#microsoft.graph.group":
+		return group
+	case "#microsoft.graph.servicePrincipal":
+		return servicePrincipal
+	case "#microsoft.graph.user":
+		return user
+	}
+
+	return directoryObject
+}
+
+var domain = entity{
+	properties: join(
+		same("authenticationType", "isDefault", "isInitial", "isRoot", "isVerified"),
+		[]property{
+			{aad: "name", ms: "id"},
+		},
+	),
+}
+
+var appRoleAssignment = entity{
+	properties: join(
+		same("principalDisplayName", "principalId", "principalType", "resourceDisplayName", "resourceId"),
+		[]property{
+			{aad: "objectId", ms: "id"},
+			{aad: "id", ms: "appRoleId"},
+			{aad: "creationTimestamp", ms: "createdDateTime"},
+		},
+	),
+}
+
+var oauth2PermissionGrant = entity{
+	properties: join(
+		same("clientId", "consentType", "principalId", "resourceId", "scope"),
+		[]property{
+			{aad: "objectId", ms: "id"},
+		},
+	),
+	ignored: []string{"expiryTime", "startTime"},
+}
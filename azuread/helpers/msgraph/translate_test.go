@@ -0,0 +1,130 @@
+package msgraph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+)
+
+func TestApplication_toMicrosoftGraph(t *testing.T) {
+	in, err := toMap(graphrbac.ApplicationCreateParameters{
+		DisplayName:             p.String("test"),
+		AvailableToOtherTenants: p.Bool(true),
+		Homepage:                p.String("https://homepage"),
+		ReplyUrls:               &[]string{"https://reply"},
+		Oauth2AllowImplicitFlow: p.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("Error converting parameters: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"displayName":    "test",
+		"signInAudience": "AzureADMultipleOrgs",
+		"web": map[string]interface{}{
+			"homePageUrl":  "https://homepage",
+			"redirectUris": []interface{}{"https://reply"},
+			"implicitGrantSettings": map[string]interface{}{
+				"enableAccessTokenIssuance": true,
+			},
+		},
+	}
+
+	if actual := application.toMicrosoftGraph(in); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestApplication_fromMicrosoftGraph(t *testing.T) {
+	in := map[string]interface{}{
+		"id":             "00000000-0000-0000-0000-000000000001",
+		"appId":          "00000000-0000-0000-0000-000000000002",
+		"displayName":    "test",
+		"signInAudience": "AzureADMyOrg",
+		"web": map[string]interface{}{
+			"logoutUrl": "https://logout",
+		},
+		"api": map[string]interface{}{
+			"oauth2PermissionScopes": []interface{}{
+				map[string]interface{}{"value": "user_impersonation"},
+			},
+		},
+		// shares the name of an Azure AD Graph property with a different type, so must not be copied
+		"tags": []interface{}{"test"},
+	}
+
+	var app graphrbac.Application
+	if err := fromMap(application.fromMicrosoftGraph(in), &app); err != nil {
+		t.Fatalf("Error populating the model: %+v", err)
+	}
+
+	if app.ObjectID == nil || *app.ObjectID != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("Expected the Object ID to be mapped from `id`, got %v", app.ObjectID)
+	}
+	if app.AvailableToOtherTenants == nil || *app.AvailableToOtherTenants {
+		t.Fatalf("Expected AvailableToOtherTenants to be false for AzureADMyOrg")
+	}
+	// the vendored model has no fields for these, so they're found in the additional properties
+	if app.AdditionalProperties["logoutUrl"] != "https://logout" {
+		t.Fatalf("Expected the logout URL to be mapped from `web.logoutUrl`, got %v", app.AdditionalProperties["logoutUrl"])
+	}
+	if permissions, ok := app.AdditionalProperties["oauth2Permissions"].([]interface{}); !ok || len(permissions) != 1 {
+		t.Fatalf("Expected one OAuth2 permission mapped from `api.oauth2PermissionScopes`, got %v", app.AdditionalProperties["oauth2Permissions"])
+	}
+	if app.ObjectType != graphrbac.ObjectTypeApplication {
+		t.Fatalf("Expected the object type to be set, got %q", app.ObjectType)
+	}
+}
+
+func TestUser_roundTrip(t *testing.T) {
+	in, err := toMap(graphrbac.UserCreateParameters{
+		AccountEnabled:    p.Bool(true),
+		DisplayName:       p.String("Test User"),
+		MailNickname:      p.String("test"),
+		UserPrincipalName: p.String("test@example.com"),
+		ImmutableID:       p.String("immutable"),
+		PasswordProfile: &graphrbac.PasswordProfile{
+			Password:                     p.String("secret"),
+			ForceChangePasswordNextLogin: p.Bool(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error converting parameters: %+v", err)
+	}
+
+	ms := user.toMicrosoftGraph(in)
+	if ms["onPremisesImmutableId"] != "immutable" {
+		t.Fatalf("Expected `immutableId` to be mapped to `onPremisesImmutableId`, got %+v", ms)
+	}
+	profile, ok := ms["passwordProfile"].(map[string]interface{})
+	if !ok || profile["forceChangePasswordNextSignIn"] != true {
+		t.Fatalf("Expected the password profile to be translated, got %+v", ms["passwordProfile"])
+	}
+
+	var u graphrbac.User
+	if err := fromMap(user.fromMicrosoftGraph(ms), &u); err != nil {
+		t.Fatalf("Error populating the model: %+v", err)
+	}
+	if u.ImmutableID == nil || *u.ImmutableID != "immutable" {
+		t.Fatalf("Expected the immutable ID to survive a round trip, got %v", u.ImmutableID)
+	}
+}
+
+func TestDirectoryObjectEntity(t *testing.T) {
+	cases := map[string]string{
+		"#microsoft.graph.application":      "Application",
+		"#microsoft.graph.group":            "Group",
+		"#microsoft.graph.servicePrincipal": "ServicePrincipal",
+		"#microsoft.graph.user":             "User",
+		"#microsoft.graph.device":           "DirectoryObject",
+	}
+
+	for odataType, objectType := range cases {
+		m := map[string]interface{}{"@odata.type": odataType, "id": "id"}
+		if actual := directoryObjectEntity(m).fromMicrosoftGraph(m)["objectType"]; actual != objectType {
+			t.Errorf("Expected %q to have the object type %q, got %q", odataType, objectType, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// UsersClient is the Microsoft Graph implementation of graph.UsersAPI
+type UsersClient struct {
+	BaseClient
+}
+
+func NewUsersClientWithBaseURI(endpoint string) UsersClient {
+	return UsersClient{NewWithBaseURI(endpoint)}
+}
+
+const usersTypeName = "msgraph.UsersClient"
+
+// selectUserProperties is the `$select` query needed to retrieve the properties the provider uses
+func selectUserProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"$select": strings.Join(userProperties, ","),
+	}
+}
+
+// Create creates a new User
+func (client UsersClient) Create(ctx context.Context, parameters graphrbac.UserCreateParameters) (result graphrbac.User, err error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return result, err
+	}
+
+	m, resp, err := client.object(ctx, usersTypeName, "Create", request{
+		method: http.MethodPost,
+		path:   "/users",
+		body:   user.toMicrosoftGraph(body),
+	})
+	if err == nil {
+		err = fromMap(user.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Get retrieves a User by its User Principal Name or Object ID
+func (client UsersClient) Get(ctx context.Context, upnOrObjectID string) (result graphrbac.User, err error) {
+	m, resp, err := client.object(ctx, usersTypeName, "Get", request{
+		method:     http.MethodGet,
+		path:       "/users/{id}",
+		parameters: map[string]interface{}{"id": upnOrObjectID},
+		query:      selectUserProperties(),
+	})
+	if err == nil {
+		err = fromMap(user.fromMicrosoftGraph(m), &result)
+	}
+	result.Response = resp
+	return result, err
+}
+
+// Update updates the specified properties of a User
+func (client UsersClient) Update(ctx context.Context, upnOrObjectID string, parameters graphrbac.UserUpdateParameters) (autorest.Response, error) {
+	body, err := toMap(parameters)
+	if err != nil {
+		return autorest.Response{}, err
+	}
+
+	return client.send(ctx, usersTypeName, "Update", request{
+		method:     http.MethodPatch,
+		path:       "/users/{id}",
+		parameters: map[string]interface{}{"id": upnOrObjectID},
+		body:       user.toMicrosoftGraph(body),
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// Delete deletes a User
+func (client UsersClient) Delete(ctx context.Context, upnOrObjectID string) (autorest.Response, error) {
+	return client.send(ctx, usersTypeName, "Delete", request{
+		method:     http.MethodDelete,
+		path:       "/users/{id}",
+		parameters: map[string]interface{}{"id": upnOrObjectID},
+	}, []int{http.StatusOK, http.StatusNoContent}, nil)
+}
+
+// List returns the first page of Users matching the filter
+func (client UsersClient) List(ctx context.Context, filter string) (graphrbac.UserListResultPage, error) {
+	query := filterQuery(filter)
+	for k, v := range selectUserProperties() {
+		query[k] = v
+	}
+
+	p := client.pager(usersTypeName, request{
+		method: http.MethodGet,
+		path:   "/users",
+		query:  query,
+	})
+
+	page := graphrbac.NewUserListResultPage(func(ctx context.Context, _ graphrbac.UserListResult) (result graphrbac.UserListResult, err error) {
+		values, resp, err := p.next(ctx)
+		if err == nil {
+			err = fromMap(translateList(values, entityOf(user)), &result)
+		}
+		result.Response = resp
+		return result, err
+	})
+
+	return page, page.NextWithContext(ctx)
+}
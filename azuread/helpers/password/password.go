@@ -0,0 +1,78 @@
+package password
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultLength is the length of a generated password when none is specified
+	DefaultLength = 32
+
+	// DefaultCharacters are the characters a generated password is made from when none are specified, the symbols are
+	// a subset of those accepted by Azure AD for both user passwords and application secrets
+	DefaultCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&*()-_=+[]{}<>:?"
+)
+
+// the number of attempts made at generating a password containing every class of character before giving up, which
+// is only reached when the length is too short to include them all
+const maxAttempts = 1000
+
+// Generate returns a cryptographically random password of the given length drawn from the given characters. Every
+// class of character (lower case, upper case, digits and symbols) present in the characters appears at least once,
+// so that the password meets the complexity requirements of Azure AD.
+func Generate(length int, characters string) (string, error) {
+	if length <= 0 {
+		length = DefaultLength
+	}
+	if characters == "" {
+		characters = DefaultCharacters
+	}
+
+	runes := []rune(characters)
+	max := big.NewInt(int64(len(runes)))
+
+	required := classesOf(runes)
+	if len(required) > length {
+		return "", fmt.Errorf("a password of length %d can't contain all %d classes of character in %q", length, len(required), characters)
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		result := make([]rune, length)
+		for i := range result {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", fmt.Errorf("Error generating password: %+v", err)
+			}
+			result[i] = runes[n.Int64()]
+		}
+
+		if len(classesOf(result)) == len(required) {
+			return string(result), nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to generate a password of length %d containing every class of character in %q", length, characters)
+}
+
+func classesOf(runes []rune) map[string]bool {
+	classes := make(map[string]bool)
+
+	for _, r := range runes {
+		switch {
+		case unicode.IsLower(r):
+			classes["lower"] = true
+		case unicode.IsUpper(r):
+			classes["upper"] = true
+		case unicode.IsDigit(r):
+			classes["digit"] = true
+		case strings.TrimSpace(string(r)) != "":
+			classes["symbol"] = true
+		}
+	}
+
+	return classes
+}
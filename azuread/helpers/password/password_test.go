@@ -0,0 +1,75 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Length     int
+		Characters string
+		Expected   int
+		Error      bool
+	}{
+		{
+			Name:     "Defaults",
+			Expected: DefaultLength,
+		},
+		{
+			Name:       "Custom",
+			Length:     64,
+			Characters: "abcDEF123",
+			Expected:   64,
+		},
+		{
+			Name:       "Single Class",
+			Length:     8,
+			Characters: "x",
+			Expected:   8,
+		},
+		{
+			Name:       "Too Short",
+			Length:     3,
+			Characters: "aA1!",
+			Error:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			characters := tc.Characters
+			if characters == "" {
+				characters = DefaultCharacters
+			}
+
+			for i := 0; i < 100; i++ {
+				value, err := Generate(tc.Length, tc.Characters)
+				if tc.Error {
+					if err == nil {
+						t.Fatalf("Expected an error but got %q", value)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %+v", err)
+				}
+
+				if len([]rune(value)) != tc.Expected {
+					t.Fatalf("Expected a password of length %d but got %q", tc.Expected, value)
+				}
+
+				for _, r := range value {
+					if !strings.ContainsRune(characters, r) {
+						t.Fatalf("Expected %q to only contain characters from %q", value, characters)
+					}
+				}
+
+				if len(classesOf([]rune(value))) != len(classesOf([]rune(characters))) {
+					t.Fatalf("Expected %q to contain every class of character in %q", value, characters)
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package tf
 
 func ExpandStringSlicePtr(input []interface{}) *[]string {
+	result := ExpandStringSlice(input)
+	return &result
+}
+
+func ExpandStringSlice(input []interface{}) []string {
 	result := make([]string, 0)
 	for _, item := range input {
 		result = append(result, item.(string))
 	}
-	return &result
+	return result
 }
 
 func FlattenStringSlicePtr(input *[]string) []interface{} {
@@ -2,11 +2,15 @@ package azuread
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
-	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
 // armMutexKV is the instance of MutexKV for ARM resources
@@ -20,6 +24,7 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SUBSCRIPTION_ID", ""),
+				Deprecated:  "This field is no longer used and will be removed in version 1.0 of the AzureAD Provider, as a Subscription is not required. The account to use from the Azure CLI is selected using `tenant_id`.",
 			},
 
 			"client_id": {
@@ -34,6 +39,15 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_TENANT_ID", ""),
 			},
 
+			"auxiliary_tenant_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
+
 			"environment": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -41,6 +55,20 @@ func Provider() terraform.ResourceProvider {
 			},
 
 			// Client Certificate specific fields
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE", ""),
+			},
+
+			"client_certificate_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_KEY", ""),
+			},
+
 			"client_certificate_password": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -60,6 +88,24 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_SECRET", ""),
 			},
 
+			// OIDC specific fields
+			"use_oidc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_OIDC", false),
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_OIDC_TOKEN", ""),
+			},
+			"oidc_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ARM_OIDC_TOKEN_FILE_PATH", "AZURE_FEDERATED_TOKEN_FILE"}, ""),
+			},
+
 			// Managed Service Identity specific fields
 			"use_msi": {
 				Type:        schema.TypeBool,
@@ -71,26 +117,65 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_MSI_ENDPOINT", ""),
 			},
+
+			// Request settings
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_RETRIES", 8),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"use_microsoft_graph": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_MSGRAPH", false),
+			},
+			"strict_import": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_PROVIDER_STRICT", false),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"azuread_application":       dataApplication(),
 			"azuread_domains":           dataDomains(),
 			"azuread_group":             dataGroup(),
+			"azuread_groups":            dataGroups(),
 			"azuread_service_principal": dataServicePrincipal(),
 			"azuread_user":              dataSourceUser(),
+			"azuread_users":             dataSourceUsers(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"azuread_application":                resourceApplication(),
-			"azuread_application_password":       resourceApplicationPassword(),
-			"azuread_group":                      resourceGroup(),
-			"azuread_service_principal":          resourceServicePrincipal(),
-			"azuread_service_principal_password": resourceServicePrincipalPassword(),
-			"azuread_user":                       resourceUser(),
+			"azuread_application":                                  resourceApplication(),
+			"azuread_application_certificate":                      resourceApplicationCertificate(),
+			"azuread_application_owner":                            resourceApplicationOwner(),
+			"azuread_application_password":                         resourceApplicationPassword(),
+			"azuread_group":                                        resourceGroup(),
+			"azuread_group_member":                                 resourceGroupMember(),
+			"azuread_service_principal":                            resourceServicePrincipal(),
+			"azuread_service_principal_app_role_assignment":        resourceServicePrincipalAppRoleAssignment(),
+			"azuread_service_principal_certificate":                resourceServicePrincipalCertificate(),
+			"azuread_service_principal_delegated_permission_grant": resourceServicePrincipalDelegatedPermissionGrant(),
+			"azuread_service_principal_password":                   resourceServicePrincipalPassword(),
+			"azuread_user":                                         resourceUser(),
 		},
 	}
 
+	for _, r := range p.DataSourcesMap {
+		withTenantDiagnostics(r)
+	}
+	for _, r := range p.ResourcesMap {
+		withTenantDiagnostics(r)
+	}
+
 	p.ConfigureFunc = providerConfigure(p)
 
 	return p
@@ -99,33 +184,41 @@ func Provider() terraform.ResourceProvider {
 func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 	return func(d *schema.ResourceData) (interface{}, error) {
 		builder := &authentication.Builder{
-			// TODO: remove the requirement on the Subscription ID
-			SubscriptionID:     d.Get("subscription_id").(string),
 			ClientID:           d.Get("client_id").(string),
 			ClientSecret:       d.Get("client_secret").(string),
 			TenantID:           d.Get("tenant_id").(string),
+			AuxiliaryTenantIDs: auxiliaryTenantIDs(d),
 			Environment:        d.Get("environment").(string),
 			MsiEndpoint:        d.Get("msi_endpoint").(string),
 			ClientCertPassword: d.Get("client_certificate_password").(string),
 			ClientCertPath:     d.Get("client_certificate_path").(string),
+			ClientCert:         d.Get("client_certificate").(string),
+			ClientCertKey:      d.Get("client_certificate_key").(string),
+			OIDCToken:          d.Get("oidc_token").(string),
+			OIDCTokenFilePath:  d.Get("oidc_token_file_path").(string),
 
 			// Feature Toggles
 			SupportsClientCertAuth:         true,
 			SupportsClientSecretAuth:       true,
+			SupportsOIDCAuth:               d.Get("use_oidc").(bool),
 			SupportsManagedServiceIdentity: d.Get("use_msi").(bool),
 			SupportsAzureCliToken:          true,
 		}
 
 		config, err := builder.Build()
 		if err != nil {
+			if builder.TenantID != "" {
+				return nil, fmt.Errorf("Error building AzureAD Client for tenant %q: %s", builder.TenantID, err)
+			}
 			return nil, fmt.Errorf("Error building AzureAD Client: %s", err)
 		}
 
-		client, err := getArmClient(config)
+		client, err := getArmClient(config, clientOptions(d))
 		if err != nil {
 			return nil, err
 		}
 
+		client.requireResourcesToBeImported = d.Get("strict_import").(bool)
 		client.StopContext = p.StopContext()
 
 		// replaces the context between tests
@@ -137,3 +230,77 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 		return client, nil
 	}
 }
+
+// auxiliaryTenantIDs returns the auxiliary tenants from the configuration, or otherwise from the semicolon separated
+// ARM_AUXILIARY_TENANT_IDS Environment Variable, since lists can't have a default
+func auxiliaryTenantIDs(d *schema.ResourceData) []string {
+	tenantIds := make([]string, 0)
+
+	if v, ok := d.GetOk("auxiliary_tenant_ids"); ok {
+		for _, id := range v.([]interface{}) {
+			tenantIds = append(tenantIds, id.(string))
+		}
+		return tenantIds
+	}
+
+	for _, id := range strings.Split(os.Getenv("ARM_AUXILIARY_TENANT_IDS"), ";") {
+		if id = strings.TrimSpace(id); id != "" {
+			tenantIds = append(tenantIds, id)
+		}
+	}
+	return tenantIds
+}
+
+func clientOptions(d *schema.ResourceData) ClientOptions {
+	return ClientOptions{
+		MaxRetries:           d.Get("max_retries").(int),
+		MaxRequestsPerSecond: d.Get("max_requests_per_second").(int),
+		UseMicrosoftGraph:    d.Get("use_microsoft_graph").(bool),
+	}
+}
+
+// withTenantDiagnostics wraps the functions of a Resource or Data Source so that any error names the tenant it
+// occurred in, which otherwise can't be told apart when many aliased providers manage different tenants
+func withTenantDiagnostics(r *schema.Resource) {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			return tenantError(meta, f(d, meta))
+		}
+	}
+
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			ok, err := exists(d, meta)
+			return ok, tenantError(meta, err)
+		}
+	}
+
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			result, err := state(d, meta)
+			return result, tenantError(meta, err)
+		}
+	}
+}
+
+func tenantError(meta interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	client, ok := meta.(*ArmClient)
+	if !ok || client.tenantID == "" {
+		return err
+	}
+
+	return fmt.Errorf("%s (tenant %q)", err, client.tenantID)
+}
@@ -4,17 +4,42 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/fakegraph"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
+// testAccFakeGraph is the fake Graph API the acceptance tests run against when ARM_TEST_OFFLINE is set
+var testAccFakeGraph *fakegraph.Server
+
+// testAccFakeGraphReplicationDelay is the replication delay of testAccFakeGraph given by ARM_TEST_OFFLINE_REPLICATION_DELAY
+var testAccFakeGraphReplicationDelay time.Duration
+
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
+	if testAccOffline() {
+		testAccFakeGraph = fakegraph.NewServer("00000000-0000-0000-0000-000000000000")
+		if v := os.Getenv("ARM_TEST_OFFLINE_REPLICATION_DELAY"); v != "" {
+			delay, err := time.ParseDuration(v)
+			if err != nil {
+				panic(fmt.Sprintf("Error parsing `ARM_TEST_OFFLINE_REPLICATION_DELAY`: %+v", err))
+			}
+			testAccFakeGraphReplicationDelay = delay
+			testAccFakeGraph.SetReplicationDelay(delay)
+		}
+		testAccProvider.ConfigureFunc = testAccOfflineProviderConfigure(testAccProvider, testAccFakeGraph)
+	}
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"azuread": testAccProvider,
 	}
@@ -30,14 +55,38 @@ func TestProvider_impl(t *testing.T) {
 	var _ = Provider()
 }
 
+func TestProvider_tenantDiagnostics(t *testing.T) {
+	r := &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return fmt.Errorf("Error retrieving Application")
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	withTenantDiagnostics(r)
+
+	client := &ArmClient{tenantID: "00000000-0000-0000-0000-000000000001"}
+
+	err := r.Read(nil, client)
+	if err == nil || !strings.Contains(err.Error(), client.tenantID) {
+		t.Fatalf("Expected the error to name the tenant, got: %v", err)
+	}
+
+	if err := r.Delete(nil, client); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
+	if testAccOffline() {
+		return
+	}
+
 	variables := []string{
-		"ARM_SUBSCRIPTION_ID",
 		"ARM_CLIENT_ID",
 		"ARM_CLIENT_SECRET",
 		"ARM_TENANT_ID",
-		"ARM_TEST_LOCATION",
-		"ARM_TEST_LOCATION_ALT",
 	}
 
 	for _, variable := range variables {
@@ -52,3 +101,71 @@ func testRequiresImportError(resourceName string) *regexp.Regexp {
 	message := "to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information."
 	return regexp.MustCompile(fmt.Sprintf(message, resourceName))
 }
+
+// testAccRequireResourcesToBeImported returns whether the acceptance tests run with strict import mode enabled
+func testAccRequireResourcesToBeImported() bool {
+	strict, _ := strconv.ParseBool(os.Getenv("ARM_PROVIDER_STRICT"))
+	return strict
+}
+
+// testAccStrictImportProviders returns a separate instance of the provider for the tests which enable strict import
+// mode with testAccProviderStrictImport, so that the setting doesn't carry over to the tests using the shared instance.
+// The shared instance is configured too when it hasn't been yet, as the checks and destroy checks use its client.
+func testAccStrictImportProviders() map[string]terraform.ResourceProvider {
+	p := Provider().(*schema.Provider)
+	if testAccOffline() {
+		p.ConfigureFunc = testAccOfflineProviderConfigure(p, testAccFakeGraph)
+	}
+
+	configure := p.ConfigureFunc
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		if testAccProvider.Meta() == nil {
+			if err := testAccProvider.Configure(terraform.NewResourceConfig(nil)); err != nil {
+				return nil, err
+			}
+		}
+
+		return configure(d)
+	}
+
+	return map[string]terraform.ResourceProvider{
+		"azuread": p,
+	}
+}
+
+// testAccProviderStrictImport is a provider block enabling strict import mode, for the tests of resources which must
+// refuse to create an object that already exists regardless of how the acceptance tests are run
+func testAccProviderStrictImport() string {
+	return `
+provider "azuread" {
+  strict_import = true
+}
+`
+}
+
+// testAccOffline returns whether the acceptance tests should be run against an in-process fake of the Graph API
+// rather than a real tenant, which requires no credentials or network access
+func testAccOffline() bool {
+	return os.Getenv("ARM_TEST_OFFLINE") != ""
+}
+
+func testAccOfflineProviderConfigure(p *schema.Provider, server *fakegraph.Server) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		config := &authentication.Config{
+			ClientID: "00000000-0000-0000-0000-000000000000",
+			TenantID: server.TenantID,
+		}
+
+		client := buildArmClient(config, azure.PublicCloud, server.Endpoint(), autorest.NullAuthorizer{}, clientOptions(d))
+		client.requireResourcesToBeImported = d.Get("strict_import").(bool)
+		client.StopContext = p.StopContext()
+
+		// replaces the context between tests
+		p.MetaReset = func() error { //nolint unparam
+			client.StopContext = p.StopContext()
+			return nil
+		}
+
+		return client, nil
+	}
+}
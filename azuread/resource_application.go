@@ -1,15 +1,22 @@
 package azuread
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
-	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
@@ -28,6 +35,13 @@ func resourceApplication() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -129,49 +143,130 @@ func resourceApplication() *schema.Resource {
 				},
 			},
 
-			"oauth2_permissions": {
-				Type:     schema.TypeList,
+			"app_role": {
+				Type:       schema.TypeSet,
+				Optional:   true,
+				Computed:   true,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Set:        resourceApplicationAppRoleHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validate.UUID,
+						},
+
+						"allowed_member_types": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice(
+									[]string{"User", "Application"},
+									false,
+								),
+							},
+						},
+
+						"description": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"display_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"is_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"value": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+					},
+				},
+			},
+
+			"owners": {
+				Type:     schema.TypeSet,
+				Optional: true,
 				Computed: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
+
+			"oauth2_permissions": {
+				Type:       schema.TypeSet,
+				Optional:   true,
+				Computed:   true,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Set:        resourceApplicationOAuth2PermissionHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"admin_consent_description": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
 						},
 
 						"admin_consent_display_name": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
 						},
 
 						"id": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validate.UUID,
 						},
 
 						"is_enabled": {
 							Type:     schema.TypeBool,
-							Computed: true,
+							Optional: true,
+							Default:  true,
 						},
 
 						"type": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
+							Default:  "User",
+							ValidateFunc: validation.StringInSlice(
+								[]string{"Admin", "User"},
+								false,
+							),
 						},
 
 						"user_consent_description": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
 
 						"user_consent_display_name": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
 
 						"value": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
 						},
 					},
 				},
@@ -182,7 +277,8 @@ func resourceApplication() *schema.Resource {
 
 func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	name := d.Get("name").(string)
 	appType := d.Get("type")
@@ -193,6 +289,17 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existing, err := graph.ApplicationFindByDisplayName(client, ctx, name)
+		if err != nil {
+			return fmt.Errorf("Error checking for existing Application %q: %+v", name, err)
+		}
+
+		if existing != nil && existing.ObjectID != nil {
+			return tf.ImportAsExistsError("azuread_application", *existing.ObjectID)
+		}
+	}
+
 	properties := graphrbac.ApplicationCreateParameters{
 		AdditionalProperties:    make(map[string]interface{}),
 		DisplayName:             &name,
@@ -202,6 +309,14 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 		RequiredResourceAccess:  expandADApplicationRequiredResourceAccess(d),
 	}
 
+	if v, ok := d.GetOk("app_role"); ok {
+		appRoles, err := expandADApplicationAppRoles(v.(*schema.Set).List(), nil)
+		if err != nil {
+			return err
+		}
+		properties.AppRoles = appRoles
+	}
+
 	if v, ok := d.GetOk("homepage"); ok {
 		properties.Homepage = p.String(v.(string))
 	} else {
@@ -229,13 +344,10 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.SetId(*app.ObjectID)
 
-	// mimicking the behaviour of az tool retry until a successful get
-	if err := resource.Retry(3*time.Minute, func() *resource.RetryError {
-		if _, err := client.Get(ctx, *app.ObjectID); err != nil {
-			return resource.RetryableError(err)
-		}
-
-		return nil
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := graph.WaitForCreation(timeout, func() (autorest.Response, error) {
+		resp, err := client.Get(ctx, *app.ObjectID)
+		return resp.Response, err
 	}); err != nil {
 		return fmt.Errorf("Error waiting for Application %q to become available: %+v", name, err)
 	}
@@ -255,6 +367,43 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 		if _, err := client.Patch(ctx, *app.ObjectID, properties); err != nil {
 			return err
 		}
+
+		if err := graph.ApplicationWaitForChanges(client, ctx, timeout, *app.ObjectID, properties); err != nil {
+			return err
+		}
+	}
+
+	// the API makes the caller an owner of the application, which has to be removed when it isn't configured
+	if v, ok := d.GetOk("owners"); ok {
+		owners := v.(*schema.Set)
+
+		existing, err := graph.ApplicationAllOwners(client, ctx, *app.ObjectID)
+		if err != nil {
+			return err
+		}
+		existingOwners := schema.NewSet(schema.HashString, tf.FlattenStringSlicePtr(&existing))
+
+		// add the configured owners first, the API refuses to remove the last owner of an application
+		toAdd := tf.ExpandStringSlice(owners.Difference(existingOwners).List())
+		if err := graph.ApplicationAddOwners(client, ctx, timeout, *app.ObjectID, toAdd); err != nil {
+			return err
+		}
+
+		toRemove := tf.ExpandStringSlice(existingOwners.Difference(owners).List())
+		if err := graph.ApplicationRemoveOwners(client, ctx, *app.ObjectID, toRemove); err != nil {
+			return err
+		}
+
+		if err := graph.ApplicationWaitForOwners(client, ctx, timeout, *app.ObjectID, tf.ExpandStringSlice(owners.List()), toRemove); err != nil {
+			return err
+		}
+	}
+
+	// the API creates a default `user_impersonation` scope which has to be replaced with any configured scopes
+	if v, ok := d.GetOk("oauth2_permissions"); ok {
+		if err := resourceApplicationUpdateOAuth2Permissions(ctx, d, meta, timeout, v.(*schema.Set).List()); err != nil {
+			return err
+		}
 	}
 
 	return resourceApplicationRead(d, meta)
@@ -262,7 +411,8 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	name := d.Get("name").(string)
 
@@ -299,6 +449,28 @@ func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
 		properties.RequiredResourceAccess = expandADApplicationRequiredResourceAccess(d)
 	}
 
+	if d.HasChange("app_role") {
+		app, err := client.Get(ctx, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving Azure AD Application with ID %q: %+v", d.Id(), err)
+		}
+
+		appRoles, err := expandADApplicationAppRoles(d.Get("app_role").(*schema.Set).List(), app.AppRoles)
+		if err != nil {
+			return err
+		}
+
+		// app roles must be disabled before they can be removed, the API rejects the removal of an enabled role
+		if disabledRoles, ok := graph.AppRolesDisableRemoved(app.AppRoles, appRoles); ok {
+			log.Printf("[DEBUG] Disabling App Roles on Azure AD Application with ID %q prior to removal", d.Id())
+			if _, err := client.Patch(ctx, d.Id(), graphrbac.ApplicationUpdateParameters{AppRoles: disabledRoles}); err != nil {
+				return fmt.Errorf("Error disabling App Roles for Azure AD Application with ID %q: %+v", d.Id(), err)
+			}
+		}
+
+		properties.AppRoles = appRoles
+	}
+
 	if d.HasChange("group_membership_claims") {
 		groupMembershipClaims := d.Get("group_membership_claims").(string)
 
@@ -327,12 +499,89 @@ func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error patching Azure AD Application with ID %q: %+v", d.Id(), err)
 	}
 
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if err := graph.ApplicationWaitForChanges(client, ctx, timeout, d.Id(), properties); err != nil {
+		return err
+	}
+
+	if d.HasChange("oauth2_permissions") {
+		if err := resourceApplicationUpdateOAuth2Permissions(ctx, d, meta, timeout, d.Get("oauth2_permissions").(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("owners") {
+		azureADLockByName(resourceApplicationName, d.Id())
+		defer azureADUnlockByName(resourceApplicationName, d.Id())
+
+		oldRaw, newRaw := d.GetChange("owners")
+		oldOwners := oldRaw.(*schema.Set)
+		newOwners := newRaw.(*schema.Set)
+
+		// add the new owners first, the API refuses to remove the last owner of an application
+		toAdd := tf.ExpandStringSlice(newOwners.Difference(oldOwners).List())
+		if err := graph.ApplicationAddOwners(client, ctx, timeout, d.Id(), toAdd); err != nil {
+			return err
+		}
+
+		toRemove := tf.ExpandStringSlice(oldOwners.Difference(newOwners).List())
+		if err := graph.ApplicationRemoveOwners(client, ctx, d.Id(), toRemove); err != nil {
+			return err
+		}
+
+		if err := graph.ApplicationWaitForOwners(client, ctx, timeout, d.Id(), toAdd, toRemove); err != nil {
+			return err
+		}
+	}
+
 	return resourceApplicationRead(d, meta)
 }
 
+// resourceApplicationUpdateOAuth2Permissions replaces the scopes exposed by the application, any scopes being
+// removed are disabled first since the API refuses to remove a scope which is still enabled
+func resourceApplicationUpdateOAuth2Permissions(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration, input []interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+
+	app, err := client.Get(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving Azure AD Application with ID %q: %+v", d.Id(), err)
+	}
+
+	existing, _ := app.AdditionalProperties["oauth2Permissions"].([]interface{})
+
+	permissions, err := expandADApplicationOAuth2Permissions(input, existing)
+	if err != nil {
+		return err
+	}
+
+	if disabled, ok := graph.OAuth2PermissionsDisableRemoved(existing, permissions); ok {
+		log.Printf("[DEBUG] Disabling OAuth2 Permissions on Azure AD Application with ID %q prior to removal", d.Id())
+		properties := graphrbac.ApplicationUpdateParameters{
+			AdditionalProperties: map[string]interface{}{
+				"oauth2Permissions": disabled,
+			},
+		}
+		if _, err := client.Patch(ctx, d.Id(), properties); err != nil {
+			return fmt.Errorf("Error disabling OAuth2 Permissions for Azure AD Application with ID %q: %+v", d.Id(), err)
+		}
+	}
+
+	properties := graphrbac.ApplicationUpdateParameters{
+		AdditionalProperties: map[string]interface{}{
+			"oauth2Permissions": permissions,
+		},
+	}
+	if _, err := client.Patch(ctx, d.Id(), properties); err != nil {
+		return fmt.Errorf("Error setting OAuth2 Permissions for Azure AD Application with ID %q: %+v", d.Id(), err)
+	}
+
+	return graph.ApplicationWaitForChanges(client, ctx, timeout, d.Id(), properties)
+}
+
 func resourceApplicationRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	resp, err := client.Get(ctx, d.Id())
 	if err != nil {
@@ -374,16 +623,30 @@ func resourceApplicationRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error setting `required_resource_access`: %+v", err)
 	}
 
+	if err := d.Set("app_role", flattenADApplicationAppRoles(resp.AppRoles)); err != nil {
+		return fmt.Errorf("Error setting `app_role`: %+v", err)
+	}
+
 	if oauth2Permissions, ok := resp.AdditionalProperties["oauth2Permissions"].([]interface{}); ok {
 		d.Set("oauth2_permissions", flattenADApplicationOauth2Permissions(oauth2Permissions))
 	}
 
+	owners, err := graph.ApplicationAllOwners(client, ctx, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("owners", owners); err != nil {
+		return fmt.Errorf("Error setting `owners`: %+v", err)
+	}
+
 	return nil
 }
 
 func resourceApplicationDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	// in order to delete an application which is available to other tenants, we first have to disable this setting
 	availableToOtherTenants := d.Get("available_to_other_tenants").(bool)
@@ -487,6 +750,183 @@ func flattenADApplicationResourceAccess(in *[]graphrbac.ResourceAccess) []interf
 	return accesses
 }
 
+func expandADApplicationAppRoles(input []interface{}, existing *[]graphrbac.AppRole) (*[]graphrbac.AppRole, error) {
+	result := make([]graphrbac.AppRole, 0)
+
+	// the ID of an existing role is only retained by a single role, and never one which is given explicitly
+	retained := make(map[string]bool)
+	for _, raw := range input {
+		if v := raw.(map[string]interface{})["id"].(string); v != "" {
+			retained[strings.ToLower(v)] = true
+		}
+	}
+	retain := func(role *graphrbac.AppRole) *string {
+		if role == nil || role.ID == nil || retained[strings.ToLower(*role.ID)] {
+			return nil
+		}
+		retained[strings.ToLower(*role.ID)] = true
+		return role.ID
+	}
+
+	for _, raw := range input {
+		appRole := raw.(map[string]interface{})
+
+		role := graphrbac.AppRole{
+			AllowedMemberTypes: tf.ExpandStringSlicePtr(appRole["allowed_member_types"].(*schema.Set).List()),
+			Description:        p.String(appRole["description"].(string)),
+			DisplayName:        p.String(appRole["display_name"].(string)),
+			IsEnabled:          p.Bool(appRole["is_enabled"].(bool)),
+		}
+
+		if v := appRole["value"].(string); v != "" {
+			role.Value = p.String(v)
+		}
+
+		// retain the ID of an existing role with the same value, or the same display name for roles without a value, so
+		// that changing another property of a role updates it rather than replacing it
+		if v := appRole["id"].(string); v != "" {
+			role.ID = p.String(v)
+		} else if id := retain(graph.AppRoleFindByValue(existing, role.Value)); id != nil {
+			role.ID = id
+		} else if existingRole := graph.AppRoleFindByDisplayNameWithoutValue(existing, role.DisplayName); role.Value == nil && retain(existingRole) != nil {
+			role.ID = existingRole.ID
+		} else {
+			id, err := uuid.GenerateUUID()
+			if err != nil {
+				return nil, fmt.Errorf("Error generating ID for App Role: %+v", err)
+			}
+			role.ID = p.String(id)
+		}
+
+		result = append(result, role)
+	}
+
+	return &result, nil
+}
+
+func flattenADApplicationAppRoles(in *[]graphrbac.AppRole) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	appRoles := make([]interface{}, 0, len(*in))
+	for _, role := range *in {
+		appRole := make(map[string]interface{})
+		if role.ID != nil {
+			appRole["id"] = *role.ID
+		}
+		if role.AllowedMemberTypes != nil {
+			appRole["allowed_member_types"] = schema.NewSet(schema.HashString, tf.FlattenStringSlicePtr(role.AllowedMemberTypes))
+		}
+		if role.Description != nil {
+			appRole["description"] = *role.Description
+		}
+		if role.DisplayName != nil {
+			appRole["display_name"] = *role.DisplayName
+		}
+		if role.IsEnabled != nil {
+			appRole["is_enabled"] = *role.IsEnabled
+		}
+		if role.Value != nil {
+			appRole["value"] = *role.Value
+		}
+		appRoles = append(appRoles, appRole)
+	}
+
+	return appRoles
+}
+
+// the `id` is excluded from the hash so that a generated ID doesn't cause a diff against the configuration
+func resourceApplicationAppRoleHash(v interface{}) int {
+	var buf bytes.Buffer
+
+	if m, ok := v.(map[string]interface{}); ok {
+		if v, ok := m["allowed_member_types"]; ok {
+			memberTypes := make([]string, 0)
+			switch types := v.(type) {
+			case *schema.Set:
+				memberTypes = tf.ExpandStringSlice(types.List())
+			case []interface{}:
+				memberTypes = tf.ExpandStringSlice(types)
+			}
+			sort.Strings(memberTypes)
+			buf.WriteString(fmt.Sprintf("%s-", strings.Join(memberTypes, ",")))
+		}
+		if v, ok := m["description"]; ok {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+		if v, ok := m["display_name"]; ok {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+		if v, ok := m["is_enabled"]; ok {
+			buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
+		}
+		if v, ok := m["value"]; ok {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+	}
+
+	return hashcode.String(buf.String())
+}
+
+// the `id` is excluded from the hash so that a generated ID doesn't cause a diff against the configuration, every field
+// is written even when missing since scopes read from the API omit the empty user consent fields
+func resourceApplicationOAuth2PermissionHash(v interface{}) int {
+	var buf bytes.Buffer
+
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, k := range []string{"admin_consent_description", "admin_consent_display_name", "type", "user_consent_description", "user_consent_display_name", "value"} {
+			s, _ := m[k].(string)
+			buf.WriteString(fmt.Sprintf("%s-", s))
+		}
+		enabled, _ := m["is_enabled"].(bool)
+		buf.WriteString(fmt.Sprintf("%t-", enabled))
+	}
+
+	return hashcode.String(buf.String())
+}
+
+func expandADApplicationOAuth2Permissions(input []interface{}, existing []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, 0)
+
+	for _, raw := range input {
+		permission := raw.(map[string]interface{})
+
+		value := permission["value"].(string)
+		apiPermission := map[string]interface{}{
+			"adminConsentDescription": permission["admin_consent_description"].(string),
+			"adminConsentDisplayName": permission["admin_consent_display_name"].(string),
+			"isEnabled":               permission["is_enabled"].(bool),
+			"type":                    permission["type"].(string),
+			"value":                   value,
+		}
+
+		if v := permission["user_consent_description"].(string); v != "" {
+			apiPermission["userConsentDescription"] = v
+		}
+		if v := permission["user_consent_display_name"].(string); v != "" {
+			apiPermission["userConsentDisplayName"] = v
+		}
+
+		// retain the ID of an existing scope with the same value so that it isn't recreated
+		if v := permission["id"].(string); v != "" {
+			apiPermission["id"] = v
+		} else if id := graph.OAuth2PermissionFindIdByValue(existing, value); id != "" {
+			apiPermission["id"] = id
+		} else {
+			id, err := uuid.GenerateUUID()
+			if err != nil {
+				return nil, fmt.Errorf("Error generating ID for OAuth2 Permission: %+v", err)
+			}
+			apiPermission["id"] = id
+		}
+
+		result = append(result, apiPermission)
+	}
+
+	return result, nil
+}
+
 func flattenADApplicationOauth2Permissions(in []interface{}) []map[string]interface{} {
 	if in == nil {
 		return []map[string]interface{}{}
@@ -500,7 +940,7 @@ func flattenADApplicationOauth2Permissions(in []interface{}) []map[string]interf
 			permission["admin_consent_description"] = v
 		}
 		if v := rawPermission["adminConsentDisplayName"]; v != nil {
-			permission["admin_consent_description"] = v
+			permission["admin_consent_display_name"] = v
 		}
 		if v := rawPermission["id"]; v != nil {
 			permission["id"] = v
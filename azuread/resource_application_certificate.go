@@ -0,0 +1,169 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+)
+
+func resourceApplicationCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApplicationCertificateCreate,
+		Read:   resourceApplicationCertificateRead,
+		Delete: resourceApplicationCertificateDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: graph.CertificateResourceSchema("application"),
+	}
+}
+
+func resourceApplicationCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	objectId := d.Get("application_id").(string)
+
+	cred, err := graph.KeyCredentialForResource(d)
+	if err != nil {
+		return fmt.Errorf("Error generating Application Certificate for Object ID %q: %+v", objectId, err)
+	}
+	id := graph.KeyCredentialIdFrom(objectId, *cred.KeyID)
+
+	azureADLockByName(resourceApplicationName, id.ObjectId)
+	defer azureADUnlockByName(resourceApplicationName, id.ObjectId)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	// the application may have only just been created
+	var existingCreds graphrbac.KeyCredentialListResult
+	if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		var err error
+		existingCreds, err = client.ListKeyCredentials(ctx, id.ObjectId)
+		return existingCreds.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error Listing Application Certificates for Object ID %q: %+v", id.ObjectId, err)
+	}
+
+	newCreds, err := graph.KeyCredentialResultAdd(existingCreds, cred, meta.(*ArmClient).requireResourcesToBeImported)
+	if err != nil {
+		return tf.ImportAsExistsError("azuread_application_certificate", id.String())
+	}
+
+	if _, err = client.UpdateKeyCredentials(ctx, id.ObjectId, graphrbac.KeyCredentialsUpdateParameters{Value: newCreds}); err != nil {
+		return fmt.Errorf("Error creating Application Certificate %q for Object ID %q: %+v", *cred.KeyID, id.ObjectId, err)
+	}
+
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		creds, err := client.ListKeyCredentials(ctx, id.ObjectId)
+		return creds.Response, err == nil && graph.KeyCredentialResultFindByKeyId(creds, id.KeyId) != nil, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Application Certificate %q for Object ID %q to replicate: %+v", id.KeyId, id.ObjectId, err)
+	}
+
+	d.SetId(id.String())
+
+	return resourceApplicationCertificateRead(d, meta)
+}
+
+func resourceApplicationCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := graph.ParseKeyCredentialId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Application Certificate ID: %v", err)
+	}
+
+	// ensure the Application Object exists
+	app, err := client.Get(ctx, id.ObjectId)
+	if err != nil {
+		// the parent Application has been removed - skip it
+		if ar.ResponseWasNotFound(app.Response) {
+			log.Printf("[DEBUG] Application with Object ID %q was not found - removing from state!", id.ObjectId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Application ID %q: %+v", id.ObjectId, err)
+	}
+
+	credentials, err := client.ListKeyCredentials(ctx, id.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error Listing Application Certificates for Application with Object ID %q: %+v", id.ObjectId, err)
+	}
+
+	credential := graph.KeyCredentialResultFindByKeyId(credentials, id.KeyId)
+	if credential == nil {
+		log.Printf("[DEBUG] Application Certificate %q (ID %q) was not found - removing from state!", id.KeyId, id.ObjectId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("application_id", id.ObjectId)
+	d.Set("key_id", id.KeyId)
+
+	if endDate := credential.EndDate; endDate != nil {
+		d.Set("end_date", endDate.Format(time.RFC3339))
+	}
+
+	if startDate := credential.StartDate; startDate != nil {
+		d.Set("start_date", startDate.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func resourceApplicationCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := graph.ParseKeyCredentialId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Application Certificate ID: %v", err)
+	}
+
+	azureADLockByName(resourceApplicationName, id.ObjectId)
+	defer azureADUnlockByName(resourceApplicationName, id.ObjectId)
+
+	// ensure the parent Application exists
+	app, err := client.Get(ctx, id.ObjectId)
+	if err != nil {
+		// the parent Application has been removed - skip it
+		if ar.ResponseWasNotFound(app.Response) {
+			log.Printf("[DEBUG] Application with Object ID %q was not found - removing from state!", id.ObjectId)
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Application ID %q: %+v", id.ObjectId, err)
+	}
+
+	existing, err := client.ListKeyCredentials(ctx, id.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error Listing Application Certificates for %q: %+v", id.ObjectId, err)
+	}
+
+	newCreds := graph.KeyCredentialResultRemoveByKeyId(existing, id.KeyId)
+	if _, err = client.UpdateKeyCredentials(ctx, id.ObjectId, graphrbac.KeyCredentialsUpdateParameters{Value: newCreds}); err != nil {
+		return fmt.Errorf("Error removing Application Certificate %q from Application Object ID %q: %+v", id.KeyId, id.ObjectId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+// a self-signed certificate valid from 2026-10-18T09:19:33Z until 2036-10-15T09:19:33Z
+const testCertificatePEM = `-----BEGIN CERTIFICATE-----
+MIIDBTCCAe2gAwIBAgIURTAFswAjz4AdO0MzMEZw8unYNTswDQYJKoZIhvcNAQEL
+BQAwEjEQMA4GA1UEAwwHYWNjdGVzdDAeFw0yNjEwMTgwOTE5MzNaFw0zNjEwMTUw
+OTE5MzNaMBIxEDAOBgNVBAMMB2FjY3Rlc3QwggEiMA0GCSqGSIb3DQEBAQUAA4IB
+DwAwggEKAoIBAQDCYN+DSapdMGH2H1vv0qeYLlxoV5UBbd2gybjovaQ9fDicPs/V
+FRvGv/jVAoNDUsZ+t2+eJI/RDQbGQzpfW5NQPSyHbdt35gZLPcnhgD8kDID2SSIk
+N5PrHbO1oWWoQaS6qIJqRlbRsFCfwrKOdL/AFB3FbilNcmL473aBWKhpIVBWpeLU
+CTUaNMkY9VWgrBDPaFTA/cR0M+OGV2ki/9/JD08xjeMbgaKLnzuT0VSxh/fIv6ed
+tUNmLV5CFj2s/P0FgmDxKiJP++UAbmPqNHxeFggvOhqyOFGYsEWFvderyalhuMRu
+UqMzACTYmU8GOSu586GyCP91w15VA5DRBOFlAgMBAAGjUzBRMB0GA1UdDgQWBBTN
+QGaIm9lY5fM8nex7ARIx9Y463DAfBgNVHSMEGDAWgBTNQGaIm9lY5fM8nex7ARIx
+9Y463DAPBgNVHRMBAf8EBTADAQH/MA0GCSqGSIb3DQEBCwUAA4IBAQA9beakNyUx
+gVkVx+8Q2rL2FVXgQIvekBO7nOw3VFYcnwc7QTZU/d3Jeu5i6BBYzRfRSYWq6Kbb
+2BPHuzK4q4eg2+x9OGi5lmCfMno1VCsal54w7eXjhibm0EPCXq8Nf0dLC/QtqdhD
+bt8ZYcaA1Lj5Jm187huwsH4osq3V1DniJBVI9dhw7MDZ0PLj/kkoLXSZ28JjrSe2
+CCScRGf1yZ2dgX8/yaFi0eYt9xK/dOuZKtiVqpxFnfg8OB7igCBR3SZWXYCYdKrz
+/I5Kl3s+EpPlDjxeQ53q4CLRlu4bKOrplPW1+rn2IlcaHlVeELasByuiFDdTZukQ
+Noa+fIr+WDWi
+-----END CERTIFICATE-----`
+
+const testCertificateBase64 = "MIIDBTCCAe2gAwIBAgIURTAFswAjz4AdO0MzMEZw8unYNTswDQYJKoZIhvcNAQELBQAwEjEQMA4GA1UEAwwHYWNjdGVzdDAeFw0yNjEwMTgwOTE5MzNaFw0zNjEwMTUwOTE5MzNaMBIxEDAOBgNVBAMMB2FjY3Rlc3QwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQDCYN+DSapdMGH2H1vv0qeYLlxoV5UBbd2gybjovaQ9fDicPs/VFRvGv/jVAoNDUsZ+t2+eJI/RDQbGQzpfW5NQPSyHbdt35gZLPcnhgD8kDID2SSIkN5PrHbO1oWWoQaS6qIJqRlbRsFCfwrKOdL/AFB3FbilNcmL473aBWKhpIVBWpeLUCTUaNMkY9VWgrBDPaFTA/cR0M+OGV2ki/9/JD08xjeMbgaKLnzuT0VSxh/fIv6edtUNmLV5CFj2s/P0FgmDxKiJP++UAbmPqNHxeFggvOhqyOFGYsEWFvderyalhuMRuUqMzACTYmU8GOSu586GyCP91w15VA5DRBOFlAgMBAAGjUzBRMB0GA1UdDgQWBBTNQGaIm9lY5fM8nex7ARIx9Y463DAfBgNVHSMEGDAWgBTNQGaIm9lY5fM8nex7ARIx9Y463DAPBgNVHRMBAf8EBTADAQH/MA0GCSqGSIb3DQEBCwUAA4IBAQA9beakNyUxgVkVx+8Q2rL2FVXgQIvekBO7nOw3VFYcnwc7QTZU/d3Jeu5i6BBYzRfRSYWq6Kbb2BPHuzK4q4eg2+x9OGi5lmCfMno1VCsal54w7eXjhibm0EPCXq8Nf0dLC/QtqdhDbt8ZYcaA1Lj5Jm187huwsH4osq3V1DniJBVI9dhw7MDZ0PLj/kkoLXSZ28JjrSe2CCScRGf1yZ2dgX8/yaFi0eYt9xK/dOuZKtiVqpxFnfg8OB7igCBR3SZWXYCYdKrz/I5Kl3s+EpPlDjxeQ53q4CLRlu4bKOrplPW1+rn2IlcaHlVeELasByuiFDdTZukQNoa+fIr+WDWi"
+
+func testCheckADApplicationCertificateExists(name string) resource.TestCheckFunc { //nolint unparam
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ArmClient).applicationsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		id, err := graph.ParseKeyCredentialId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Application Certificate ID: %v", err)
+		}
+		resp, err := client.Get(ctx, id.ObjectId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Azure AD Application  %q does not exist", id.ObjectId)
+			}
+			return fmt.Errorf("Bad: Get on Azure AD applicationsClient: %+v", err)
+		}
+
+		credentials, err := client.ListKeyCredentials(ctx, id.ObjectId)
+		if err != nil {
+			return fmt.Errorf("Error Listing Key Credentials for Application %q: %+v", id.ObjectId, err)
+		}
+
+		cred := graph.KeyCredentialResultFindByKeyId(credentials, id.KeyId)
+		if cred != nil {
+			return nil
+		}
+
+		return fmt.Errorf("Key Credential %q was not found in Application %q", id.KeyId, id.ObjectId)
+	}
+}
+
+func testCheckADApplicationCertificateCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		client := testAccProvider.Meta().(*ArmClient).applicationsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		if rs.Type != "azuread_application_certificate" {
+			continue
+		}
+
+		id, err := graph.ParseKeyCredentialId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Application Certificate ID: %v", err)
+		}
+
+		resp, err := client.Get(ctx, id.ObjectId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("Azure AD Application Key Credential still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func TestAccAzureADApplicationCertificate_basic(t *testing.T) {
+	resourceName := "azuread_application_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationCertificate_basic(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationCertificateExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttr(resourceName, "start_date", "2026-10-18T09:19:33Z"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2036-10-15T09:19:33Z"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"encoding", "value"},
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationCertificate_base64(t *testing.T) {
+	resourceName := "azuread_application_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationCertificate_base64(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationCertificateExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2036-10-15T09:19:33Z"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationCertificate_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_application_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationCertificate_basic(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationCertificateExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADApplicationCertificate_requiresImport(applicationId),
+				ExpectError: testRequiresImportError("azuread_application_certificate"),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationCertificate_customKeyIdAndDates(t *testing.T) {
+	resourceName := "azuread_application_certificate.test"
+	applicationId := uuid.New().String()
+	keyId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationCertificate_customKeyIdAndDates(applicationId, keyId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationCertificateExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "key_id", keyId),
+					resource.TestCheckResourceAttr(resourceName, "start_date", "2027-01-01T01:02:03Z"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2028-01-01T01:02:03Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccADApplicationCertificate_template(applicationId string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctestspa%s"
+}
+`, applicationId)
+}
+
+func testAccADApplicationCertificate_basic(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_certificate" "test" {
+  application_id = "${azuread_application.test.id}"
+  value          = <<EOT
+%s
+EOT
+}
+`, testAccADApplicationCertificate_template(applicationId), testCertificatePEM)
+}
+
+func testAccADApplicationCertificate_base64(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_certificate" "test" {
+  application_id = "${azuread_application.test.id}"
+  encoding       = "base64"
+  value          = "%s"
+}
+`, testAccADApplicationCertificate_template(applicationId), testCertificateBase64)
+}
+
+func testAccADApplicationCertificate_requiresImport(applicationId string) string {
+	template := testAccADApplicationCertificate_basic(applicationId)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_certificate" "import" {
+  application_id = "${azuread_application_certificate.test.application_id}"
+  key_id         = "${azuread_application_certificate.test.key_id}"
+  value          = "${azuread_application_certificate.test.value}"
+}
+`, template)
+}
+
+func testAccADApplicationCertificate_customKeyIdAndDates(applicationId, keyId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_certificate" "test" {
+  application_id = "${azuread_application.test.id}"
+  key_id         = "%s"
+  start_date     = "2027-01-01T01:02:03Z"
+  end_date       = "2028-01-01T01:02:03Z"
+  value          = <<EOT
+%s
+EOT
+}
+`, testAccADApplicationCertificate_template(applicationId), keyId, testCertificatePEM)
+}
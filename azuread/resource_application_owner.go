@@ -0,0 +1,147 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+func resourceApplicationOwner() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApplicationOwnerCreate,
+		Read:   resourceApplicationOwnerRead,
+		Delete: resourceApplicationOwnerDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"owner_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+		},
+	}
+}
+
+func resourceApplicationOwnerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	id := graph.ApplicationOwnerIdFrom(d.Get("application_object_id").(string), d.Get("owner_object_id").(string))
+
+	azureADLockByName(resourceApplicationName, id.ApplicationId)
+	defer azureADUnlockByName(resourceApplicationName, id.ApplicationId)
+
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existingOwners, err := graph.ApplicationAllOwners(client, ctx, id.ApplicationId)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range existingOwners {
+			if v == id.OwnerId {
+				return tf.ImportAsExistsError("azuread_application_owner", id.String())
+			}
+		}
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := graph.ApplicationAddOwner(client, ctx, timeout, id.ApplicationId, id.OwnerId); err != nil {
+		return err
+	}
+
+	if err := graph.ApplicationWaitForOwners(client, ctx, timeout, id.ApplicationId, []string{id.OwnerId}, nil); err != nil {
+		return err
+	}
+
+	d.SetId(id.String())
+
+	return resourceApplicationOwnerRead(d, meta)
+}
+
+func resourceApplicationOwnerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := graph.ParseApplicationOwnerId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Application Owner ID: %v", err)
+	}
+
+	// ensure the Application Object exists
+	app, err := client.Get(ctx, id.ApplicationId)
+	if err != nil {
+		if ar.ResponseWasNotFound(app.Response) {
+			log.Printf("[DEBUG] Application with Object ID %q was not found - removing from state!", id.ApplicationId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Application ID %q: %+v", id.ApplicationId, err)
+	}
+
+	owners, err := graph.ApplicationAllOwners(client, ctx, id.ApplicationId)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, v := range owners {
+		if v == id.OwnerId {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		log.Printf("[DEBUG] Owner %q was not found on Application %q - removing from state!", id.OwnerId, id.ApplicationId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("application_object_id", id.ApplicationId)
+	d.Set("owner_object_id", id.OwnerId)
+
+	return nil
+}
+
+func resourceApplicationOwnerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).applicationsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := graph.ParseApplicationOwnerId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Application Owner ID: %v", err)
+	}
+
+	azureADLockByName(resourceApplicationName, id.ApplicationId)
+	defer azureADUnlockByName(resourceApplicationName, id.ApplicationId)
+
+	return graph.ApplicationRemoveOwners(client, ctx, id.ApplicationId, []string{id.OwnerId})
+}
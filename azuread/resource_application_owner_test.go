@@ -0,0 +1,171 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+func TestAccAzureADApplicationOwner_basic(t *testing.T) {
+	resourceName := "azuread_application_owner.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationOwnerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationOwner_basic(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationOwnerExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "application_object_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "owner_object_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationOwner_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_application_owner.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationOwnerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationOwner_basic(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationOwnerExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADApplicationOwner_requiresImport(id, password),
+				ExpectError: testRequiresImportError("azuread_application_owner"),
+			},
+		},
+	})
+}
+
+func testCheckADApplicationOwnerExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).applicationsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		id, err := graph.ParseApplicationOwnerId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Application Owner ID: %v", err)
+		}
+
+		owners, err := graph.ApplicationAllOwners(client, ctx, id.ApplicationId)
+		if err != nil {
+			return fmt.Errorf("Bad: ListOwners on Azure AD applicationsClient: %+v", err)
+		}
+
+		for _, v := range owners {
+			if v == id.OwnerId {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Bad: Owner %q was not found on Azure AD Application %q", id.OwnerId, id.ApplicationId)
+	}
+}
+
+func testCheckADApplicationOwnerDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azuread_application_owner" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).applicationsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		id, err := graph.ParseApplicationOwnerId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Application Owner ID: %v", err)
+		}
+
+		resp, err := client.Get(ctx, id.ApplicationId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		owners, err := graph.ApplicationAllOwners(client, ctx, id.ApplicationId)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range owners {
+			if v == id.OwnerId {
+				return fmt.Errorf("Azure AD Application Owner %q still exists on Application %q", id.OwnerId, id.ApplicationId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccADApplicationOwner_basic(id, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_application" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_application_owner" "test" {
+  application_object_id = "${azuread_application.test.id}"
+  owner_object_id       = "${azuread_user.test.id}"
+}
+`, id, password)
+}
+
+func testAccADApplicationOwner_requiresImport(id, password string) string {
+	template := testAccADApplicationOwner_basic(id, password)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_owner" "import" {
+  application_object_id = "${azuread_application_owner.test.application_object_id}"
+  owner_object_id       = "${azuread_application_owner.test.owner_object_id}"
+}
+`, template)
+}
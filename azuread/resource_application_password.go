@@ -1,11 +1,13 @@
 package azuread
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
@@ -16,19 +18,42 @@ func resourceApplicationPassword() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceApplicationPasswordCreate,
 		Read:   resourceApplicationPasswordRead,
+		Update: resourceApplicationPasswordUpdate,
 		Delete: resourceApplicationPasswordDelete,
 
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: resourceApplicationPasswordCustomizeDiff,
+
 		Schema: graph.PasswordResourceSchema("application"),
 	}
 }
 
+func resourceApplicationPasswordCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// Microsoft Graph generates the key ID and value of a password, so any which are configured are ignored
+	if client, ok := meta.(*ArmClient); ok {
+		if _, ok := client.applicationsClient.(graph.PasswordCredentialAdder); ok {
+			if err := graph.PasswordResourceCustomizeDiffGenerated(d); err != nil {
+				return err
+			}
+		}
+	}
+
+	return graph.PasswordResourceCustomizeDiff(d, meta)
+}
+
 func resourceApplicationPasswordCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	objectId := d.Get("application_id").(string)
 
@@ -41,28 +66,65 @@ func resourceApplicationPasswordCreate(d *schema.ResourceData, meta interface{})
 	azureADLockByName(resourceApplicationName, id.ObjectId)
 	defer azureADUnlockByName(resourceApplicationName, id.ObjectId)
 
-	existingCreds, err := client.ListPasswordCredentials(ctx, id.ObjectId)
-	if err != nil {
-		return fmt.Errorf("Error Listing Application Credentials for Object ID %q: %+v", id.ObjectId, err)
-	}
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	if adder, ok := client.(graph.PasswordCredentialAdder); ok {
+		// the application may have only just been created
+		var added graph.AddedPasswordCredential
+		if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+			var err error
+			added, err = adder.AddPasswordCredential(ctx, objectId, *cred)
+			return added.Response, err
+		}); err != nil {
+			return fmt.Errorf("Error creating Application Credentials for Object ID %q: %+v", objectId, err)
+		}
+		if added.KeyID == nil {
+			return fmt.Errorf("Key ID returned for Password Credential for Application %q was nil", objectId)
+		}
 
-	newCreds, err := graph.PasswordCredentialResultAdd(existingCreds, cred, requireResourcesToBeImported)
-	if err != nil {
-		return tf.ImportAsExistsError("azuread_application_password", id.String())
+		// the key ID and value are generated by the API
+		cred = &added.PasswordCredential
+		id = graph.PasswordCredentialIdFrom(objectId, *added.KeyID)
+	} else {
+		// the application may have only just been created
+		var existingCreds graphrbac.PasswordCredentialListResult
+		if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+			var err error
+			existingCreds, err = client.ListPasswordCredentials(ctx, id.ObjectId)
+			return existingCreds.Response, err
+		}); err != nil {
+			return fmt.Errorf("Error Listing Application Credentials for Object ID %q: %+v", id.ObjectId, err)
+		}
+
+		newCreds, err := graph.PasswordCredentialResultAdd(existingCreds, cred, meta.(*ArmClient).requireResourcesToBeImported)
+		if err != nil {
+			return tf.ImportAsExistsError("azuread_application_password", id.String())
+		}
+
+		if _, err = client.UpdatePasswordCredentials(ctx, id.ObjectId, graphrbac.PasswordCredentialsUpdateParameters{Value: newCreds}); err != nil {
+			return fmt.Errorf("Error creating Application Credentials %q for Object ID %q: %+v", *cred.KeyID, id.ObjectId, err)
+		}
 	}
 
-	if _, err = client.UpdatePasswordCredentials(ctx, id.ObjectId, graphrbac.PasswordCredentialsUpdateParameters{Value: newCreds}); err != nil {
-		return fmt.Errorf("Error creating Application Credentials %q for Object ID %q: %+v", *cred.KeyID, id.ObjectId, err)
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		creds, err := client.ListPasswordCredentials(ctx, id.ObjectId)
+		return creds.Response, err == nil && graph.PasswordCredentialResultFindByKeyId(creds, id.KeyId) != nil, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Application Credential %q for Object ID %q to replicate: %+v", id.KeyId, id.ObjectId, err)
 	}
 
 	d.SetId(id.String())
 
+	// the value is never returned when reading passwords, so keep the one which was sent or generated
+	d.Set("value", cred.Value)
+
 	return resourceApplicationPasswordRead(d, meta)
 }
 
 func resourceApplicationPasswordRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	id, err := graph.ParsePasswordCredentialId(d.Id())
 	if err != nil {
@@ -99,18 +161,31 @@ func resourceApplicationPasswordRead(d *schema.ResourceData, meta interface{}) e
 
 	if endDate := credential.EndDate; endDate != nil {
 		d.Set("end_date", endDate.Format(time.RFC3339))
+		d.Set("expires_in_days", graph.PasswordCredentialExpiresInDays(endDate.Time))
+
+		if endDate.Before(time.Now()) {
+			log.Printf("[WARN] Password Credential %q (ID %q) expired at %s", id.KeyId, id.ObjectId, endDate.Format(time.RFC3339))
+		}
 	}
 
 	if startDate := credential.StartDate; startDate != nil {
 		d.Set("start_date", startDate.Format(time.RFC3339))
 	}
 
+	d.Set("description", graph.PasswordCredentialDescription(*credential))
+
 	return nil
 }
 
+func resourceApplicationPasswordUpdate(d *schema.ResourceData, meta interface{}) error {
+	// only `rotation_days` can be updated, which is only used when planning
+	return resourceApplicationPasswordRead(d, meta)
+}
+
 func resourceApplicationPasswordDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).applicationsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	id, err := graph.ParsePasswordCredentialId(d.Id())
 	if err != nil {
@@ -1,12 +1,18 @@
 package azuread
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -102,7 +108,7 @@ func TestAccAzureADApplicationPassword_basic(t *testing.T) {
 }
 
 func TestAccAzureADApplicationPassword_requiresImport(t *testing.T) {
-	if !requireResourcesToBeImported {
+	if !testAccRequireResourcesToBeImported() {
 		t.Skip("Skipping since resources aren't required to be imported")
 		return
 	}
@@ -178,6 +184,94 @@ func TestAccAzureADApplicationPassword_relativeEndDate(t *testing.T) {
 	})
 }
 
+func TestAccAzureADApplicationPassword_rotation(t *testing.T) {
+	resourceName := "azuread_application_password.test"
+	applicationId := uuid.New().String()
+	value := uuid.New().String()
+	var id string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationPassword_rotation(applicationId, value, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rotation_days", "5"),
+					resource.TestCheckResourceAttr(resourceName, "expires_in_days", "9"),
+					testCheckResourceIdChange(resourceName, &id, false),
+				),
+			},
+			{
+				// once the password expires within the rotation window it's replaced, and only once since the
+				// replacement is valid for the full `end_date_relative` - the plan following the apply must be empty
+				PreConfig: func() {
+					testAccAgePasswordCredential(t, testAccProvider.Meta().(*ArmClient).applicationsClient, id, 48*time.Hour)
+				},
+				Config: testAccADApplicationPassword_rotation(applicationId, value, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "expires_in_days", "9"),
+					testCheckResourceIdChange(resourceName, &id, true),
+				),
+			},
+			{
+				Config:      testAccADApplicationPassword_rotation(applicationId, value, 10),
+				ExpectError: regexp.MustCompile("`rotation_days` \\(10\\) must be less than the lifetime"),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationPassword_generatedValue(t *testing.T) {
+	resourceName := "azuread_application_password.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationPassword_generatedValue(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationPasswordExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "value", regexp.MustCompile("^[a-zA-Z0-9]{40}$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplicationPassword_description(t *testing.T) {
+	resourceName := "azuread_application_password.test"
+	applicationId := uuid.New().String()
+	value := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplicationPassword_description(applicationId, value),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", "terraform-pipeline"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"end_date_relative", "value"},
+			},
+		},
+	})
+}
+
 func testAccADApplicationPassword_template(applicationId string) string {
 	return fmt.Sprintf(`
 resource "azuread_application" "test" {
@@ -236,3 +330,101 @@ resource "azuread_application_password" "test" {
 }
 `, testAccADApplicationPassword_template(applicationId), value)
 }
+
+func testAccADApplicationPassword_rotation(applicationId, value string, rotationDays int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_password" "test" {
+  application_id       = "${azuread_application.test.id}"
+  value                = "%s"
+  end_date_relative    = "240h"
+  rotation_days        = %d
+}
+`, testAccADApplicationPassword_template(applicationId), value, rotationDays)
+}
+
+func testAccADApplicationPassword_generatedValue(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_password" "test" {
+  application_id       = "${azuread_application.test.id}"
+  value_length         = 40
+  value_characters     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+  end_date_relative    = "8760h"
+}
+`, testAccADApplicationPassword_template(applicationId))
+}
+
+func testAccADApplicationPassword_description(applicationId, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_application_password" "test" {
+  application_id       = "${azuread_application.test.id}"
+  description          = "terraform-pipeline"
+  value                = "%s"
+  end_date_relative    = "8760h"
+}
+`, testAccADApplicationPassword_template(applicationId), value)
+}
+
+// testCheckResourceIdChange records the ID of a resource, and when changed is true checks that it differs from the ID
+// recorded by an earlier step, i.e. that the resource has been replaced
+func testCheckResourceIdChange(name string, id *string, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		if changed && rs.Primary.ID == *id {
+			return fmt.Errorf("Bad: expected %q to have been replaced, but it still has the ID %q", name, *id)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccAgePasswordCredential brings the end date of a password forward to expire after the given duration, as if
+// it had been created some time ago
+func testAccAgePasswordCredential(t *testing.T, client interface {
+	ListPasswordCredentials(ctx context.Context, objectID string) (graphrbac.PasswordCredentialListResult, error)
+	UpdatePasswordCredentials(ctx context.Context, objectID string, parameters graphrbac.PasswordCredentialsUpdateParameters) (autorest.Response, error)
+}, id string, expiresIn time.Duration) {
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	passwordId, err := graph.ParsePasswordCredentialId(id)
+	if err != nil {
+		t.Fatalf("Error parsing Password Credential ID: %v", err)
+	}
+
+	creds, err := client.ListPasswordCredentials(ctx, passwordId.ObjectId)
+	if err != nil {
+		t.Fatalf("Error listing Password Credentials for %q: %+v", passwordId.ObjectId, err)
+	}
+
+	endDate := date.Time{Time: time.Now().Add(expiresIn).UTC().Truncate(time.Second)}
+	for i, c := range *creds.Value {
+		if c.KeyID != nil && *c.KeyID == passwordId.KeyId {
+			(*creds.Value)[i].EndDate = &endDate
+		}
+	}
+
+	if _, err := client.UpdatePasswordCredentials(ctx, passwordId.ObjectId, graphrbac.PasswordCredentialsUpdateParameters{Value: creds.Value}); err != nil {
+		t.Fatalf("Error updating Password Credentials for %q: %+v", passwordId.ObjectId, err)
+	}
+
+	if err := graph.WaitForReplication(5*time.Minute, func() (autorest.Response, bool, error) {
+		creds, err := client.ListPasswordCredentials(ctx, passwordId.ObjectId)
+		if err != nil {
+			return creds.Response, false, err
+		}
+		cred := graph.PasswordCredentialResultFindByKeyId(creds, passwordId.KeyId)
+		return creds.Response, cred != nil && cred.EndDate != nil && cred.EndDate.Equal(endDate.Time), nil
+	}); err != nil {
+		t.Fatalf("Error waiting for the end date of Password Credential %q to replicate: %+v", id, err)
+	}
+}
@@ -3,11 +3,13 @@ package azuread
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -29,7 +31,9 @@ func TestAccAzureADApplication_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "homepage", fmt.Sprintf("https://acctest%s", id)),
 					resource.TestCheckResourceAttr(resourceName, "type", "webapp/api"),
 					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.0.admin_consent_description", fmt.Sprintf("Access %s", fmt.Sprintf("acctest%s", id))),
+					testCheckADApplicationOAuth2Permission(resourceName, "user_impersonation", map[string]string{
+						"admin_consent_description": fmt.Sprintf("Access %s", fmt.Sprintf("acctest%s", id)),
+					}),
 					resource.TestCheckResourceAttrSet(resourceName, "application_id"),
 				),
 			},
@@ -42,6 +46,29 @@ func TestAccAzureADApplication_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureADApplication_requiresImport(t *testing.T) {
+	resourceName := "azuread_application.test"
+	id := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccStrictImportProviders(),
+		CheckDestroy: testCheckADApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderStrictImport() + testAccADApplication_basic(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADApplication_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_application"),
+			},
+		},
+	})
+}
+
 func TestAccAzureADApplication_complete(t *testing.T) {
 	resourceName := "azuread_application.test"
 	id := uuid.New().String()
@@ -277,6 +304,256 @@ func TestAccAzureADApplication_native_app_does_not_allow_identifier_uris(t *test
 	})
 }
 
+func TestAccAzureADApplication_owners(t *testing.T) {
+	resourceName := "azuread_application.test"
+	id := uuid.New().String()
+	pw := "p@$$wR2" + acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplication_owners(id, pw, "azuread_user.testA"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccADApplication_owners(id, pw, "azuread_user.testA", "azuread_user.testB"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "2"),
+				),
+			},
+			{
+				Config: testAccADApplication_owners(id, pw, "azuread_user.testB"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplication_appRoles(t *testing.T) {
+	resourceName := "azuread_application.test"
+	id := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplication_appRoles(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccADApplication_appRolesUpdate(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role.#", "1"),
+				),
+			},
+			{
+				Config: testAccADApplication_appRolesNone(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplication_appRolesUpdateDescription(t *testing.T) {
+	resourceName := "azuread_application.test"
+	id := uuid.New().String()
+	ids := make(map[string]string)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplication_appRolesDescription(id, "Admins can manage roles", "Auditors can read audit logs"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role.#", "2"),
+					testCheckADApplicationAppRoleIds(resourceName, ids),
+				),
+			},
+			{
+				Config: testAccADApplication_appRolesDescription(id, "Admins can manage roles and settings", "Auditors can read and export audit logs"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role.#", "2"),
+					testCheckADApplicationAppRoleIds(resourceName, ids),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADApplication_oauth2Permissions(t *testing.T) {
+	resourceName := "azuread_application.test"
+	id := uuid.New().String()
+	ids := make(map[string]string)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADApplication_oauth2Permissions(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.#", "2"),
+					testCheckADApplicationOAuth2Permission(resourceName, "administer", map[string]string{
+						"type":                       "Admin",
+						"admin_consent_display_name": "Administer",
+					}),
+					testCheckADApplicationOAuth2Permission(resourceName, "read", map[string]string{
+						"type": "User",
+					}),
+					testCheckADApplicationOAuth2PermissionIds(resourceName, ids),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccADApplication_oauth2PermissionsInsert(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.#", "3"),
+					testCheckADApplicationOAuth2Permission(resourceName, "write", map[string]string{
+						"admin_consent_display_name": "Write",
+					}),
+					testCheckADApplicationOAuth2PermissionIds(resourceName, ids),
+				),
+			},
+			{
+				Config: testAccADApplication_oauth2PermissionsUpdate(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.#", "1"),
+					testCheckADApplicationOAuth2Permission(resourceName, "read", map[string]string{
+						"is_enabled": "false",
+					}),
+					testCheckADApplicationOAuth2PermissionIds(resourceName, ids),
+				),
+			},
+			{
+				Config: testAccADApplication_oauth2PermissionsNone(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADApplicationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oauth2_permissions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testCheckADApplicationOAuth2Permission checks the attributes of the scope with the given value, since the scopes are
+// held in a set and so can't be addressed by index
+func testCheckADApplicationOAuth2Permission(name, value string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "oauth2_permissions.") || !strings.HasSuffix(k, ".value") || v != value {
+				continue
+			}
+
+			prefix := strings.TrimSuffix(k, "value")
+			for attr, want := range expected {
+				if got := rs.Primary.Attributes[prefix+attr]; got != want {
+					return fmt.Errorf("Bad: OAuth2 Permission %q has %s %q, expected %q", value, attr, got, want)
+				}
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("Bad: OAuth2 Permission %q not found in %q", value, name)
+	}
+}
+
+// testCheckADApplicationOAuth2PermissionIds records the ID of each scope and checks that a scope seen in an earlier
+// step still has the same ID, i.e. that changing the other scopes hasn't reassigned it
+func testCheckADApplicationOAuth2PermissionIds(name string, ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		for k, value := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "oauth2_permissions.") || !strings.HasSuffix(k, ".value") {
+				continue
+			}
+
+			id := rs.Primary.Attributes[strings.TrimSuffix(k, "value")+"id"]
+			if previous, ok := ids[value]; ok && previous != id {
+				return fmt.Errorf("Bad: OAuth2 Permission %q changed ID from %q to %q", value, previous, id)
+			}
+			ids[value] = id
+		}
+
+		return nil
+	}
+}
+
+// testCheckADApplicationAppRoleIds checks the App Roles, identified by their display name, keep the ID they had in
+// previous steps
+func testCheckADApplicationAppRoleIds(name string, ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		for k, displayName := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "app_role.") || !strings.HasSuffix(k, ".display_name") {
+				continue
+			}
+
+			id := rs.Primary.Attributes[strings.TrimSuffix(k, "display_name")+"id"]
+			if previous, ok := ids[displayName]; ok && previous != id {
+				return fmt.Errorf("Bad: App Role %q changed ID from %q to %q", displayName, previous, id)
+			}
+			ids[displayName] = id
+		}
+
+		return nil
+	}
+}
+
 func testCheckADApplicationExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -331,6 +608,18 @@ resource "azuread_application" "test" {
 `, id)
 }
 
+func testAccADApplication_requiresImport(id string) string {
+	template := testAccADApplication_basic(id)
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "azuread_application" "import" {
+  name = "${azuread_application.test.name}"
+}
+`, testAccProviderStrictImport(), template)
+}
+
 func testAccADApplication_availableToOtherTenants(id string) string {
 	return fmt.Sprintf(`
 
@@ -423,3 +712,186 @@ resource "azuread_application" "test" {
 }
 `, id, id)
 }
+
+func testAccADApplication_owners(id, password string, owners ...string) string {
+	ownerIds := make([]string, 0, len(owners))
+	for _, o := range owners {
+		ownerIds = append(ownerIds, fmt.Sprintf(`"${%s.id}"`, o))
+	}
+
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "testA" {
+  user_principal_name = "acctestA%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctestA%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "testB" {
+  user_principal_name = "acctestB%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctestB%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_application" "test" {
+  name   = "acctest%[1]s"
+  owners = [%[3]s]
+}
+`, id, password, strings.Join(ownerIds, ", "))
+}
+
+func testAccADApplication_appRoles(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctest%s"
+
+  app_role {
+    allowed_member_types = ["User", "Application"]
+    description          = "Admins can manage roles and perform all task actions"
+    display_name         = "Admin"
+    is_enabled           = true
+    value                = "Admin"
+  }
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "ReadOnly roles have limited query access"
+    display_name         = "ReadOnly"
+    value                = "User"
+  }
+}
+`, id)
+}
+
+func testAccADApplication_appRolesUpdate(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctest%s"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Admins can manage roles and perform all task actions"
+    display_name         = "Admin"
+    is_enabled           = true
+    value                = "Admin"
+  }
+}
+`, id)
+}
+
+func testAccADApplication_appRolesDescription(id, adminDescription, auditorDescription string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctest%s"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "%s"
+    display_name         = "Admin"
+    value                = "Admin"
+  }
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "%s"
+    display_name         = "Auditor"
+  }
+}
+`, id, adminDescription, auditorDescription)
+}
+
+func testAccADApplication_appRolesNone(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name     = "acctest%s"
+  app_role = []
+}
+`, id)
+}
+
+func testAccADApplication_oauth2Permissions(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name            = "acctest%[1]s"
+  identifier_uris = ["http://%[1]s.hashicorptest.com"]
+
+  oauth2_permissions {
+    admin_consent_description  = "Administer the application"
+    admin_consent_display_name = "Administer"
+    is_enabled                 = true
+    type                       = "Admin"
+    value                      = "administer"
+  }
+
+  oauth2_permissions {
+    admin_consent_description  = "Read the data"
+    admin_consent_display_name = "Read"
+    user_consent_description   = "Read your data"
+    user_consent_display_name  = "Read"
+    value                      = "read"
+  }
+}
+`, id)
+}
+
+func testAccADApplication_oauth2PermissionsInsert(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name            = "acctest%[1]s"
+  identifier_uris = ["http://%[1]s.hashicorptest.com"]
+
+  oauth2_permissions {
+    admin_consent_description  = "Administer the application"
+    admin_consent_display_name = "Administer"
+    is_enabled                 = true
+    type                       = "Admin"
+    value                      = "administer"
+  }
+
+  oauth2_permissions {
+    admin_consent_description  = "Write the data"
+    admin_consent_display_name = "Write"
+    value                      = "write"
+  }
+
+  oauth2_permissions {
+    admin_consent_description  = "Read the data"
+    admin_consent_display_name = "Read"
+    user_consent_description   = "Read your data"
+    user_consent_display_name  = "Read"
+    value                      = "read"
+  }
+}
+`, id)
+}
+
+func testAccADApplication_oauth2PermissionsUpdate(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name            = "acctest%[1]s"
+  identifier_uris = ["http://%[1]s.hashicorptest.com"]
+
+  oauth2_permissions {
+    admin_consent_description  = "Read the data"
+    admin_consent_display_name = "Read"
+    is_enabled                 = false
+    user_consent_description   = "Read your data"
+    user_consent_display_name  = "Read"
+    value                      = "read"
+  }
+}
+`, id)
+}
+
+func testAccADApplication_oauth2PermissionsNone(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name               = "acctest%[1]s"
+  identifier_uris    = ["http://%[1]s.hashicorptest.com"]
+  oauth2_permissions = []
+}
+`, id)
+}
@@ -1,26 +1,42 @@
 package azuread
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
+const resourceGroupName = "azuread_group"
+
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGroupCreate,
 		Read:   resourceGroupRead,
+		Update: resourceGroupUpdate,
 		Delete: resourceGroupDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -28,16 +44,50 @@ func resourceGroup() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
+
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
+
+			"owners": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.UUID,
+				},
+			},
 		},
 	}
 }
 
 func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).groupsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	name := d.Get("name").(string)
 
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existing, err := graph.GroupFindByDisplayName(client, ctx, name)
+		if err != nil {
+			return fmt.Errorf("Error checking for existing Group %q: %+v", name, err)
+		}
+
+		if existing != nil && existing.ObjectID != nil {
+			return tf.ImportAsExistsError("azuread_group", *existing.ObjectID)
+		}
+	}
+
 	properties := graphrbac.GroupCreateParameters{
 		DisplayName:     &name,
 		MailEnabled:     p.Bool(false),                 //we're defaulting to false, as the API currently only supports the creation of non-mail enabled security groups.
@@ -50,14 +100,50 @@ func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if group.ObjectID == nil {
+		return fmt.Errorf("Group objectId is nil")
+	}
 	d.SetId(*group.ObjectID)
 
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := graph.WaitForCreation(timeout, func() (autorest.Response, error) {
+		resp, err := client.Get(ctx, *group.ObjectID)
+		return resp.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Group %q to become available: %+v", name, err)
+	}
+
+	// Add members if specified
+	if v, ok := d.GetOk("members"); ok {
+		members := tf.ExpandStringSlice(v.(*schema.Set).List())
+		if err := graph.GroupAddMembers(client, ctx, timeout, *group.ObjectID, members); err != nil {
+			return err
+		}
+
+		if err := graph.GroupWaitForMembers(client, ctx, timeout, *group.ObjectID, members, nil); err != nil {
+			return err
+		}
+	}
+
+	// Add owners if specified
+	if v, ok := d.GetOk("owners"); ok {
+		owners := tf.ExpandStringSlice(v.(*schema.Set).List())
+		if err := graph.GroupAddOwners(client, ctx, timeout, *group.ObjectID, owners); err != nil {
+			return err
+		}
+
+		if err := graph.GroupWaitForOwners(client, ctx, timeout, *group.ObjectID, owners, nil); err != nil {
+			return err
+		}
+	}
+
 	return resourceGroupRead(d, meta)
 }
 
 func resourceGroupRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).groupsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	resp, err := client.Get(ctx, d.Id())
 	if err != nil {
@@ -72,12 +158,86 @@ func resourceGroupRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", resp.DisplayName)
 
+	members, err := graph.GroupAllMembers(client, ctx, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("members", members); err != nil {
+		return fmt.Errorf("Error setting `members`: %+v", err)
+	}
+
+	owners, err := graph.GroupAllOwners(client, ctx, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("owners", owners); err != nil {
+		return fmt.Errorf("Error setting `owners`: %+v", err)
+	}
+
 	return nil
 }
 
+func resourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).groupsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	azureADLockByName(resourceGroupName, d.Id())
+	defer azureADUnlockByName(resourceGroupName, d.Id())
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("members") {
+		oldRaw, newRaw := d.GetChange("members")
+		oldMembers := oldRaw.(*schema.Set)
+		newMembers := newRaw.(*schema.Set)
+
+		// add the new members first so the group is never left empty during the update
+		toAdd := tf.ExpandStringSlice(newMembers.Difference(oldMembers).List())
+		if err := graph.GroupAddMembers(client, ctx, timeout, d.Id(), toAdd); err != nil {
+			return err
+		}
+
+		toRemove := tf.ExpandStringSlice(oldMembers.Difference(newMembers).List())
+		if err := graph.GroupRemoveMembers(client, ctx, d.Id(), toRemove); err != nil {
+			return err
+		}
+
+		if err := graph.GroupWaitForMembers(client, ctx, timeout, d.Id(), toAdd, toRemove); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("owners") {
+		oldRaw, newRaw := d.GetChange("owners")
+		oldOwners := oldRaw.(*schema.Set)
+		newOwners := newRaw.(*schema.Set)
+
+		// add the new owners first, the API refuses to remove the last owner of a group
+		toAdd := tf.ExpandStringSlice(newOwners.Difference(oldOwners).List())
+		if err := graph.GroupAddOwners(client, ctx, timeout, d.Id(), toAdd); err != nil {
+			return err
+		}
+
+		toRemove := tf.ExpandStringSlice(oldOwners.Difference(newOwners).List())
+		if err := graph.GroupRemoveOwners(client, ctx, d.Id(), toRemove); err != nil {
+			return err
+		}
+
+		if err := graph.GroupWaitForOwners(client, ctx, timeout, d.Id(), toAdd, toRemove); err != nil {
+			return err
+		}
+	}
+
+	return resourceGroupRead(d, meta)
+}
+
 func resourceGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).groupsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	if resp, err := client.Delete(ctx, d.Id()); err != nil {
 		if !ar.ResponseWasNotFound(resp) {
@@ -0,0 +1,143 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+func resourceGroupMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupMemberCreate,
+		Read:   resourceGroupMemberRead,
+		Delete: resourceGroupMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"member_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+		},
+	}
+}
+
+func resourceGroupMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).groupsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	id := graph.GroupMemberIdFrom(d.Get("group_object_id").(string), d.Get("member_object_id").(string))
+
+	azureADLockByName(resourceGroupName, id.GroupId)
+	defer azureADUnlockByName(resourceGroupName, id.GroupId)
+
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		isMember, err := graph.GroupIsMember(client, ctx, id.GroupId, id.MemberId)
+		if err != nil {
+			return fmt.Errorf("Error checking for existing membership of %q in Group %q: %+v", id.MemberId, id.GroupId, err)
+		}
+
+		if isMember {
+			return tf.ImportAsExistsError("azuread_group_member", id.String())
+		}
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := graph.GroupAddMember(client, ctx, timeout, id.GroupId, id.MemberId); err != nil {
+		return err
+	}
+
+	if err := graph.GroupWaitForMembers(client, ctx, timeout, id.GroupId, []string{id.MemberId}, nil); err != nil {
+		return err
+	}
+
+	d.SetId(id.String())
+
+	return resourceGroupMemberRead(d, meta)
+}
+
+func resourceGroupMemberRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).groupsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := graph.ParseGroupMemberId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Group Member ID: %v", err)
+	}
+
+	// ensure the parent Group exists
+	group, err := client.Get(ctx, id.GroupId)
+	if err != nil {
+		if ar.ResponseWasNotFound(group.Response) {
+			log.Printf("[DEBUG] Group with Object ID %q was not found - removing from state!", id.GroupId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Group ID %q: %+v", id.GroupId, err)
+	}
+
+	isMember, err := graph.GroupIsMember(client, ctx, id.GroupId, id.MemberId)
+	if err != nil {
+		return fmt.Errorf("Error checking membership of %q in Group %q: %+v", id.MemberId, id.GroupId, err)
+	}
+
+	if !isMember {
+		log.Printf("[DEBUG] Member %q was not found in Group %q - removing from state!", id.MemberId, id.GroupId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("group_object_id", id.GroupId)
+	d.Set("member_object_id", id.MemberId)
+
+	return nil
+}
+
+func resourceGroupMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).groupsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := graph.ParseGroupMemberId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Group Member ID: %v", err)
+	}
+
+	azureADLockByName(resourceGroupName, id.GroupId)
+	defer azureADUnlockByName(resourceGroupName, id.GroupId)
+
+	if resp, err := client.RemoveMember(ctx, id.GroupId, id.MemberId); err != nil {
+		if !ar.ResponseWasNotFound(resp) {
+			return fmt.Errorf("Error removing Member %q from Group %q: %+v", id.MemberId, id.GroupId, err)
+		}
+	}
+
+	return nil
+}
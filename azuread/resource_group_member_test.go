@@ -0,0 +1,252 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+func TestAccAzureADGroupMember_user(t *testing.T) {
+	resourceName := "azuread_group_member.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupMember_user(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupMemberExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "group_object_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "member_object_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroupMember_group(t *testing.T) {
+	resourceName := "azuread_group_member.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupMember_group(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupMemberExists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroupMember_servicePrincipal(t *testing.T) {
+	resourceName := "azuread_group_member.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupMember_servicePrincipal(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupMemberExists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroupMember_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_group_member.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupMember_group(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupMemberExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureADGroupMember_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_group_member"),
+			},
+		},
+	})
+}
+
+func testCheckAzureADGroupMemberExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).groupsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		id, err := graph.ParseGroupMemberId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Group Member ID: %v", err)
+		}
+
+		isMember, err := graph.GroupIsMember(client, ctx, id.GroupId, id.MemberId)
+		if err != nil {
+			return fmt.Errorf("Bad: IsMemberOf on Azure AD groupsClient: %+v", err)
+		}
+
+		if !isMember {
+			return fmt.Errorf("Bad: Member %q was not found in Azure AD Group %q", id.MemberId, id.GroupId)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureADGroupMemberDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azuread_group_member" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).groupsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		id, err := graph.ParseGroupMemberId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Group Member ID: %v", err)
+		}
+
+		resp, err := client.Get(ctx, id.GroupId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		isMember, err := graph.GroupIsMember(client, ctx, id.GroupId, id.MemberId)
+		if err != nil {
+			return err
+		}
+
+		if isMember {
+			return fmt.Errorf("Azure AD Group Member %q still exists in Group %q", id.MemberId, id.GroupId)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureADGroupMember_user(id, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_group" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_group_member" "test" {
+  group_object_id  = "${azuread_group.test.id}"
+  member_object_id = "${azuread_user.test.id}"
+}
+`, id, password)
+}
+
+func testAccAzureADGroupMember_group(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_group" "member" {
+  name = "acctest%[1]s-member"
+}
+
+resource "azuread_group_member" "test" {
+  group_object_id  = "${azuread_group.test.id}"
+  member_object_id = "${azuread_group.member.id}"
+}
+`, id)
+}
+
+func testAccAzureADGroupMember_servicePrincipal(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = "${azuread_application.test.application_id}"
+}
+
+resource "azuread_group" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_group_member" "test" {
+  group_object_id  = "${azuread_group.test.id}"
+  member_object_id = "${azuread_service_principal.test.id}"
+}
+`, id)
+}
+
+func testAccAzureADGroupMember_requiresImport(id string) string {
+	template := testAccAzureADGroupMember_group(id)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group_member" "import" {
+  group_object_id  = "${azuread_group_member.test.group_object_id}"
+  member_object_id = "${azuread_group_member.test.member_object_id}"
+}
+`, template)
+}
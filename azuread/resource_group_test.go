@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
@@ -39,6 +40,32 @@ func TestAccAzureADGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureADGroup_requiresImport(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccStrictImportProviders(),
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderStrictImport() + testAccAzureADGroup(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureADGroup_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_group"),
+			},
+		},
+	})
+}
+
 func TestAccAzureADGroup_complete(t *testing.T) {
 	resourceName := "azuread_group.test"
 	id, err := uuid.GenerateUUID()
@@ -68,6 +95,154 @@ func TestAccAzureADGroup_complete(t *testing.T) {
 	})
 }
 
+func TestAccAzureADGroup_members(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupWithMembers(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctest%s", id)),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroup_owners(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupWithOwners(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroup_membersUpdate(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupWithMember(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureADGroupWithMembers(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+				),
+			},
+			{
+				Config: testAccAzureADGroupWithNoMembers(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroup_membersAndOwnersOmitted(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupWithMembersAndOwners(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "1"),
+				),
+			},
+			{
+				// members and owners which aren't configured are left alone, rather than removed
+				Config: testAccAzureADGroupWithUsers(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "owners.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADGroup_timeouts(t *testing.T) {
+	resourceName := "azuread_group.test"
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADGroupTimeouts(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctest%s", id)),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
 func testCheckAzureADGroupExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -121,3 +296,116 @@ resource "azuread_group" "test" {
 }
 `, id)
 }
+
+func testAccAzureADGroup_requiresImport(id string) string {
+	template := testAccAzureADGroup(id)
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "azuread_group" "import" {
+  name = "${azuread_group.test.name}"
+}
+`, testAccProviderStrictImport(), template)
+}
+
+func testAccAzureADGroupTimeouts(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  name = "acctest%s"
+
+  timeouts {
+    create = "10m"
+    read   = "2m"
+    update = "10m"
+    delete = "2m"
+  }
+}
+`, id)
+}
+
+func testAccAzureADGroupUsers(id, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "testA" {
+  user_principal_name = "acctestA%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctestA%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "testB" {
+  user_principal_name = "acctestB%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctestB%[1]s"
+  password            = "%[2]s"
+}
+`, id, password)
+}
+
+func testAccAzureADGroupWithMember(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name    = "acctest%s"
+  members = ["${azuread_user.testA.id}"]
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
+
+func testAccAzureADGroupWithMembers(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name    = "acctest%s"
+  members = ["${azuread_user.testA.id}", "${azuread_user.testB.id}"]
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
+
+func testAccAzureADGroupWithNoMembers(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name    = "acctest%s"
+  members = []
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
+
+func testAccAzureADGroupWithUsers(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name = "acctest%s"
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
+
+func testAccAzureADGroupWithMembersAndOwners(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name    = "acctest%s"
+  members = ["${azuread_user.testA.id}", "${azuread_user.testB.id}"]
+  owners  = ["${azuread_user.testA.id}"]
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
+
+func testAccAzureADGroupWithOwners(id, password string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_group" "test" {
+  name   = "acctest%s"
+  owners = ["${azuread_user.testA.id}"]
+}
+`, testAccAzureADGroupUsers(id, password), id)
+}
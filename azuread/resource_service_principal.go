@@ -1,11 +1,13 @@
 package azuread
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
 
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
@@ -29,6 +31,12 @@ func resourceServicePrincipal() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"application_id": {
 				Type:         schema.TypeString,
@@ -57,10 +65,22 @@ func resourceServicePrincipal() *schema.Resource {
 
 func resourceServicePrincipalCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).servicePrincipalsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	applicationId := d.Get("application_id").(string)
 
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existing, err := graph.ServicePrincipalFindByAppId(client, ctx, applicationId)
+		if err != nil {
+			return fmt.Errorf("Error checking for existing Service Principal for Application %q: %+v", applicationId, err)
+		}
+
+		if existing != nil && existing.ObjectID != nil {
+			return tf.ImportAsExistsError("azuread_service_principal", *existing.ObjectID)
+		}
+	}
+
 	properties := graphrbac.ServicePrincipalCreateParameters{
 		AppID: p.String(applicationId),
 		// there's no way of retrieving this, and there's no way of changing it
@@ -71,8 +91,15 @@ func resourceServicePrincipalCreate(d *schema.ResourceData, meta interface{}) er
 		properties.Tags = tf.ExpandStringSlicePtr(v.(*schema.Set).List())
 	}
 
-	sp, err := client.Create(ctx, properties)
-	if err != nil {
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	// the application may have only just been created
+	var sp graphrbac.ServicePrincipal
+	if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		var err error
+		sp, err = client.Create(ctx, properties)
+		return sp.Response, err
+	}); err != nil {
 		return fmt.Errorf("Error creating Service Principal for application  %q: %+v", applicationId, err)
 	}
 	if sp.ObjectID == nil {
@@ -80,13 +107,9 @@ func resourceServicePrincipalCreate(d *schema.ResourceData, meta interface{}) er
 	}
 	d.SetId(*sp.ObjectID)
 
-	// mimicking the behaviour of az tool retry until a successful get
-	if err := resource.Retry(3*time.Minute, func() *resource.RetryError {
-		if _, err := client.Get(ctx, *sp.ObjectID); err != nil {
-			return resource.RetryableError(err)
-		}
-
-		return nil
+	if err := graph.WaitForCreation(timeout, func() (autorest.Response, error) {
+		resp, err := client.Get(ctx, *sp.ObjectID)
+		return resp.Response, err
 	}); err != nil {
 		return fmt.Errorf("Error waiting for Service Principal %q to become available: %+v", applicationId, err)
 	}
@@ -96,7 +119,8 @@ func resourceServicePrincipalCreate(d *schema.ResourceData, meta interface{}) er
 
 func resourceServicePrincipalRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).servicePrincipalsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	objectId := d.Id()
 
@@ -127,7 +151,8 @@ func resourceServicePrincipalRead(d *schema.ResourceData, meta interface{}) erro
 
 func resourceServicePrincipalDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).servicePrincipalsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	applicationId := d.Id()
 	app, err := client.Delete(ctx, applicationId)
@@ -0,0 +1,263 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+func resourceServicePrincipalAppRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServicePrincipalAppRoleAssignmentCreate,
+		Read:   resourceServicePrincipalAppRoleAssignmentRead,
+		Delete: resourceServicePrincipalAppRoleAssignmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"principal_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"app_role_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"app_role_value"},
+				ValidateFunc:  validate.UUID,
+			},
+
+			"app_role_value": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"app_role_id"},
+				ValidateFunc:  validate.NoEmptyStrings,
+			},
+
+			"principal_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceServicePrincipalAppRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).appRoleAssignmentsClient
+	spClient := meta.(*ArmClient).servicePrincipalsClient
+	objectsClient := meta.(*ArmClient).objectsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	resourceId := d.Get("resource_object_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	// the resource service principal, or the app role, may have only just been created
+	var role *graphrbac.AppRole
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		sp, err := spClient.Get(ctx, resourceId)
+		if err != nil {
+			return sp.Response, false, err
+		}
+
+		if v, ok := d.GetOk("app_role_id"); ok {
+			if strings.EqualFold(v.(string), graph.DefaultAccessAppRoleId) {
+				// the default access role is never one of the App Roles of the resource
+				role = &graphrbac.AppRole{ID: p.String(graph.DefaultAccessAppRoleId)}
+			} else {
+				role = graph.AppRoleFindById(sp.AppRoles, v.(string))
+			}
+		} else if v, ok := d.GetOk("app_role_value"); ok {
+			role = graph.AppRoleFindByValue(sp.AppRoles, p.String(v.(string)))
+		} else {
+			return sp.Response, false, fmt.Errorf("one of `app_role_id` or `app_role_value` must be specified")
+		}
+
+		return sp.Response, role != nil, nil
+	}); err != nil {
+		return fmt.Errorf("Error waiting for the App Role to be available on Resource Service Principal %q: %+v", resourceId, err)
+	}
+
+	// the principal may have only just been created
+	var principalType graphrbac.ObjectType
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		var err error
+		principalType, err = graph.DirectoryObjectType(objectsClient, ctx, principalId)
+		return autorest.Response{}, principalType != "", err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Principal with Object ID %q to become available: %+v", principalId, err)
+	}
+
+	azureADLockByName(servicePrincipalResourceName, resourceId)
+	defer azureADUnlockByName(servicePrincipalResourceName, resourceId)
+
+	existing, err := client.ListComplete(ctx, principalType, principalId)
+	if err != nil {
+		return fmt.Errorf("Error listing existing App Role Assignments for Principal %q: %+v", principalId, err)
+	}
+
+	for _, v := range existing {
+		if v.ResourceID == nil || v.ID == nil || v.ObjectID == nil {
+			continue
+		}
+		if strings.EqualFold(*v.ResourceID, resourceId) && strings.EqualFold(*v.ID, *role.ID) {
+			id := graph.AppRoleAssignmentIdFrom(principalId, *v.ObjectID)
+			if meta.(*ArmClient).requireResourcesToBeImported {
+				return tf.ImportAsExistsError("azuread_service_principal_app_role_assignment", id.String())
+			}
+			return fmt.Errorf("App Role %q of Resource Service Principal %q is already assigned to Principal %q", *role.ID, resourceId, principalId)
+		}
+	}
+
+	properties := graph.AppRoleAssignment{
+		ID:          role.ID,
+		PrincipalID: p.String(principalId),
+		ResourceID:  p.String(resourceId),
+	}
+
+	var assignment graph.AppRoleAssignment
+	if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		var err error
+		assignment, err = client.Create(ctx, principalType, properties)
+		return assignment.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error assigning App Role %q of Resource Service Principal %q to Principal %q: %+v", *role.ID, resourceId, principalId, err)
+	}
+	if assignment.ObjectID == nil {
+		return fmt.Errorf("App Role Assignment objectId is nil")
+	}
+
+	d.SetId(graph.AppRoleAssignmentIdFrom(principalId, *assignment.ObjectID).String())
+
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		assignments, err := client.ListComplete(ctx, principalType, principalId)
+		return autorest.Response{}, err == nil && graph.AppRoleAssignmentFindById(assignments, *assignment.ObjectID) != nil, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for App Role Assignment %q to replicate: %+v", d.Id(), err)
+	}
+
+	return resourceServicePrincipalAppRoleAssignmentRead(d, meta)
+}
+
+func resourceServicePrincipalAppRoleAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).appRoleAssignmentsClient
+	spClient := meta.(*ArmClient).servicePrincipalsClient
+	objectsClient := meta.(*ArmClient).objectsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := graph.ParseAppRoleAssignmentId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing App Role Assignment ID: %v", err)
+	}
+
+	principalType, err := graph.DirectoryObjectType(objectsClient, ctx, id.PrincipalId)
+	if err != nil {
+		return err
+	}
+	if principalType == "" {
+		log.Printf("[DEBUG] Principal with Object ID %q was not found - removing from state!", id.PrincipalId)
+		d.SetId("")
+		return nil
+	}
+
+	assignments, err := client.ListComplete(ctx, principalType, id.PrincipalId)
+	if err != nil {
+		return fmt.Errorf("Error listing App Role Assignments for Principal %q: %+v", id.PrincipalId, err)
+	}
+
+	assignment := graph.AppRoleAssignmentFindById(assignments, id.AssignmentId)
+	if assignment == nil {
+		log.Printf("[DEBUG] App Role Assignment %q was not found for Principal %q - removing from state!", id.AssignmentId, id.PrincipalId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("principal_object_id", id.PrincipalId)
+	d.Set("principal_type", string(principalType))
+	d.Set("resource_object_id", assignment.ResourceID)
+	d.Set("app_role_id", assignment.ID)
+
+	// the value of the role is not returned with the assignment, so look it up on the resource
+	appRoleValue := ""
+	if assignment.ResourceID != nil && assignment.ID != nil {
+		sp, err := spClient.Get(ctx, *assignment.ResourceID)
+		if err != nil {
+			if !ar.ResponseWasNotFound(sp.Response) {
+				return fmt.Errorf("Error retrieving Resource Service Principal %q: %+v", *assignment.ResourceID, err)
+			}
+		} else if role := graph.AppRoleFindById(sp.AppRoles, *assignment.ID); role != nil && role.Value != nil {
+			appRoleValue = *role.Value
+		}
+	}
+	d.Set("app_role_value", appRoleValue)
+
+	return nil
+}
+
+func resourceServicePrincipalAppRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).appRoleAssignmentsClient
+	objectsClient := meta.(*ArmClient).objectsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := graph.ParseAppRoleAssignmentId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing App Role Assignment ID: %v", err)
+	}
+
+	principalType, err := graph.DirectoryObjectType(objectsClient, ctx, id.PrincipalId)
+	if err != nil {
+		return err
+	}
+	if principalType == "" {
+		// the principal is gone, and its assignments with it
+		return nil
+	}
+
+	resourceId := d.Get("resource_object_id").(string)
+	azureADLockByName(servicePrincipalResourceName, resourceId)
+	defer azureADUnlockByName(servicePrincipalResourceName, resourceId)
+
+	if resp, err := client.Delete(ctx, principalType, id.PrincipalId, id.AssignmentId); err != nil {
+		if !ar.ResponseWasNotFound(resp) {
+			return fmt.Errorf("Error removing App Role Assignment %q from Principal %q: %+v", id.AssignmentId, id.PrincipalId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+func TestAccAzureADServicePrincipalAppRoleAssignment_group(t *testing.T) {
+	resourceName := "azuread_service_principal_app_role_assignment.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalAppRoleAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalAppRoleAssignment_group(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalAppRoleAssignmentExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "app_role_id"),
+					resource.TestCheckResourceAttr(resourceName, "app_role_value", "User.Access"),
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "Group"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalAppRoleAssignment_servicePrincipal(t *testing.T) {
+	resourceName := "azuread_service_principal_app_role_assignment.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalAppRoleAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalAppRoleAssignment_servicePrincipal(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalAppRoleAssignmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role_value", "Task.ReadWrite"),
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "ServicePrincipal"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalAppRoleAssignment_defaultAccess(t *testing.T) {
+	resourceName := "azuread_service_principal_app_role_assignment.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalAppRoleAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalAppRoleAssignment_defaultAccess(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalAppRoleAssignmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app_role_id", graph.DefaultAccessAppRoleId),
+					resource.TestCheckResourceAttr(resourceName, "app_role_value", ""),
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "User"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalAppRoleAssignment_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_service_principal_app_role_assignment.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalAppRoleAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalAppRoleAssignment_group(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalAppRoleAssignmentExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureADServicePrincipalAppRoleAssignment_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_service_principal_app_role_assignment"),
+			},
+		},
+	})
+}
+
+func testCheckAzureADServicePrincipalAppRoleAssignmentFind(rs *terraform.ResourceState) (*graph.AppRoleAssignment, error) {
+	client := testAccProvider.Meta().(*ArmClient).appRoleAssignmentsClient
+	objectsClient := testAccProvider.Meta().(*ArmClient).objectsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	id, err := graph.ParseAppRoleAssignmentId(rs.Primary.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing App Role Assignment ID: %v", err)
+	}
+
+	principalType, err := graph.DirectoryObjectType(objectsClient, ctx, id.PrincipalId)
+	if err != nil {
+		return nil, err
+	}
+	if principalType == "" {
+		return nil, nil
+	}
+
+	assignments, err := client.ListComplete(ctx, principalType, id.PrincipalId)
+	if err != nil {
+		return nil, fmt.Errorf("Bad: listing App Role Assignments for Principal %q: %+v", id.PrincipalId, err)
+	}
+
+	return graph.AppRoleAssignmentFindById(assignments, id.AssignmentId), nil
+}
+
+func testCheckAzureADServicePrincipalAppRoleAssignmentExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		assignment, err := testCheckAzureADServicePrincipalAppRoleAssignmentFind(rs)
+		if err != nil {
+			return err
+		}
+
+		if assignment == nil {
+			return fmt.Errorf("Bad: App Role Assignment %q does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureADServicePrincipalAppRoleAssignmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azuread_service_principal_app_role_assignment" {
+			continue
+		}
+
+		assignment, err := testCheckAzureADServicePrincipalAppRoleAssignmentFind(rs)
+		if err != nil {
+			return err
+		}
+
+		if assignment != nil {
+			return fmt.Errorf("App Role Assignment %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureADServicePrincipalAppRoleAssignment_template(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctest%[1]s"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Users can access the application"
+    display_name         = "User"
+    value                = "User.Access"
+  }
+
+  app_role {
+    allowed_member_types = ["Application"]
+    description          = "Applications can read and write tasks"
+    display_name         = "Tasks"
+    value                = "Task.ReadWrite"
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = "${azuread_application.test.application_id}"
+}
+`, id)
+}
+
+func testAccAzureADServicePrincipalAppRoleAssignment_group(id string) string {
+	template := testAccAzureADServicePrincipalAppRoleAssignment_template(id)
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  name = "acctest%[2]s"
+}
+
+resource "azuread_service_principal_app_role_assignment" "test" {
+  resource_object_id  = "${azuread_service_principal.test.id}"
+  principal_object_id = "${azuread_group.test.id}"
+  app_role_value      = "User.Access"
+}
+`, template, id)
+}
+
+func testAccAzureADServicePrincipalAppRoleAssignment_servicePrincipal(id string) string {
+	template := testAccAzureADServicePrincipalAppRoleAssignment_template(id)
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "client" {
+  name = "acctest%[2]s-client"
+}
+
+resource "azuread_service_principal" "client" {
+  application_id = "${azuread_application.client.application_id}"
+}
+
+resource "azuread_service_principal_app_role_assignment" "test" {
+  resource_object_id  = "${azuread_service_principal.test.id}"
+  principal_object_id = "${azuread_service_principal.client.id}"
+  app_role_value      = "Task.ReadWrite"
+}
+`, template, id)
+}
+
+func testAccAzureADServicePrincipalAppRoleAssignment_defaultAccess(id, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_application" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = "${azuread_application.test.application_id}"
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[1]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_service_principal_app_role_assignment" "test" {
+  resource_object_id  = "${azuread_service_principal.test.id}"
+  principal_object_id = "${azuread_user.test.id}"
+  app_role_id         = "%[3]s"
+}
+`, id, password, graph.DefaultAccessAppRoleId)
+}
+
+func testAccAzureADServicePrincipalAppRoleAssignment_requiresImport(id string) string {
+	template := testAccAzureADServicePrincipalAppRoleAssignment_group(id)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_app_role_assignment" "import" {
+  resource_object_id  = "${azuread_service_principal_app_role_assignment.test.resource_object_id}"
+  principal_object_id = "${azuread_service_principal_app_role_assignment.test.principal_object_id}"
+  app_role_id         = "${azuread_service_principal_app_role_assignment.test.app_role_id}"
+}
+`, template)
+}
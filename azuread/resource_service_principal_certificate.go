@@ -0,0 +1,169 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+)
+
+func resourceServicePrincipalCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServicePrincipalCertificateCreate,
+		Read:   resourceServicePrincipalCertificateRead,
+		Delete: resourceServicePrincipalCertificateDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: graph.CertificateResourceSchema("service_principal"),
+	}
+}
+
+func resourceServicePrincipalCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).servicePrincipalsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	objectId := d.Get("service_principal_id").(string)
+
+	cred, err := graph.KeyCredentialForResource(d)
+	if err != nil {
+		return fmt.Errorf("Error generating Service Principal Certificate for Object ID %q: %+v", objectId, err)
+	}
+	id := graph.KeyCredentialIdFrom(objectId, *cred.KeyID)
+
+	azureADLockByName(servicePrincipalResourceName, id.ObjectId)
+	defer azureADUnlockByName(servicePrincipalResourceName, id.ObjectId)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	// the service principal may have only just been created
+	var existingCreds graphrbac.KeyCredentialListResult
+	if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		var err error
+		existingCreds, err = client.ListKeyCredentials(ctx, id.ObjectId)
+		return existingCreds.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error Listing Service Principal Certificates for Object ID %q: %+v", id.ObjectId, err)
+	}
+
+	newCreds, err := graph.KeyCredentialResultAdd(existingCreds, cred, meta.(*ArmClient).requireResourcesToBeImported)
+	if err != nil {
+		return tf.ImportAsExistsError("azuread_service_principal_certificate", id.String())
+	}
+
+	if _, err = client.UpdateKeyCredentials(ctx, id.ObjectId, graphrbac.KeyCredentialsUpdateParameters{Value: newCreds}); err != nil {
+		return fmt.Errorf("Error creating Service Principal Certificate %q for Object ID %q: %+v", *cred.KeyID, id.ObjectId, err)
+	}
+
+	if err := graph.WaitForReplication(timeout, func() (autorest.Response, bool, error) {
+		creds, err := client.ListKeyCredentials(ctx, id.ObjectId)
+		return creds.Response, err == nil && graph.KeyCredentialResultFindByKeyId(creds, id.KeyId) != nil, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Service Principal Certificate %q for Object ID %q to replicate: %+v", id.KeyId, id.ObjectId, err)
+	}
+
+	d.SetId(id.String())
+
+	return resourceServicePrincipalCertificateRead(d, meta)
+}
+
+func resourceServicePrincipalCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).servicePrincipalsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := graph.ParseKeyCredentialId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Service Principal Certificate ID: %v", err)
+	}
+
+	// ensure the Service Principal exists
+	servicePrincipal, err := client.Get(ctx, id.ObjectId)
+	if err != nil {
+		// the parent Service Principal has been removed - skip it
+		if ar.ResponseWasNotFound(servicePrincipal.Response) {
+			log.Printf("[DEBUG] Service Principal with Object ID %q was not found - removing from state!", id.ObjectId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Service Principal ID %q: %+v", id.ObjectId, err)
+	}
+
+	credentials, err := client.ListKeyCredentials(ctx, id.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error Listing Service Principal Certificates for Service Principal with Object ID %q: %+v", id.ObjectId, err)
+	}
+
+	credential := graph.KeyCredentialResultFindByKeyId(credentials, id.KeyId)
+	if credential == nil {
+		log.Printf("[DEBUG] Service Principal Certificate %q (ID %q) was not found - removing from state!", id.KeyId, id.ObjectId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("service_principal_id", id.ObjectId)
+	d.Set("key_id", id.KeyId)
+
+	if endDate := credential.EndDate; endDate != nil {
+		d.Set("end_date", endDate.Format(time.RFC3339))
+	}
+
+	if startDate := credential.StartDate; startDate != nil {
+		d.Set("start_date", startDate.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func resourceServicePrincipalCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).servicePrincipalsClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := graph.ParseKeyCredentialId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Service Principal Certificate ID: %v", err)
+	}
+
+	azureADLockByName(servicePrincipalResourceName, id.ObjectId)
+	defer azureADUnlockByName(servicePrincipalResourceName, id.ObjectId)
+
+	// ensure the parent Service Principal exists
+	servicePrincipal, err := client.Get(ctx, id.ObjectId)
+	if err != nil {
+		// the parent Service Principal has been removed - skip it
+		if ar.ResponseWasNotFound(servicePrincipal.Response) {
+			log.Printf("[DEBUG] Service Principal with Object ID %q was not found - removing from state!", id.ObjectId)
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Service Principal ID %q: %+v", id.ObjectId, err)
+	}
+
+	existing, err := client.ListKeyCredentials(ctx, id.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error Listing Service Principal Certificates for %q: %+v", id.ObjectId, err)
+	}
+
+	newCreds := graph.KeyCredentialResultRemoveByKeyId(existing, id.KeyId)
+	if _, err = client.UpdateKeyCredentials(ctx, id.ObjectId, graphrbac.KeyCredentialsUpdateParameters{Value: newCreds}); err != nil {
+		return fmt.Errorf("Error removing Service Principal Certificate %q from Service Principal Object ID %q: %+v", id.KeyId, id.ObjectId, err)
+	}
+
+	return nil
+}
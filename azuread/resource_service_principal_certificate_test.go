@@ -0,0 +1,243 @@
+package azuread
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+)
+
+func testCheckADServicePrincipalCertificateExists(name string) resource.TestCheckFunc { //nolint unparam
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ArmClient).servicePrincipalsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		id, err := graph.ParseKeyCredentialId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Service Principal Certificate ID: %v", err)
+		}
+		resp, err := client.Get(ctx, id.ObjectId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Azure AD Service Principal %q does not exist", id.ObjectId)
+			}
+			return fmt.Errorf("Bad: Get on Azure AD servicePrincipalsClient: %+v", err)
+		}
+
+		credentials, err := client.ListKeyCredentials(ctx, id.ObjectId)
+		if err != nil {
+			return fmt.Errorf("Error Listing Key Credentials for Service Principal %q: %+v", id.ObjectId, err)
+		}
+
+		cred := graph.KeyCredentialResultFindByKeyId(credentials, id.KeyId)
+		if cred != nil {
+			return nil
+		}
+
+		return fmt.Errorf("Key Credential %q was not found in Service Principal %q", id.KeyId, id.ObjectId)
+	}
+}
+
+func testCheckADServicePrincipalCertificateCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		client := testAccProvider.Meta().(*ArmClient).servicePrincipalsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		if rs.Type != "azuread_service_principal_certificate" {
+			continue
+		}
+
+		id, err := graph.ParseKeyCredentialId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing Service Principal Certificate ID: %v", err)
+		}
+
+		resp, err := client.Get(ctx, id.ObjectId)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("Azure AD Service Principal Key Credential still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func TestAccAzureADServicePrincipalCertificate_basic(t *testing.T) {
+	resourceName := "azuread_service_principal_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalCertificate_basic(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalCertificateExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttr(resourceName, "start_date", "2026-10-18T09:19:33Z"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2036-10-15T09:19:33Z"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"encoding", "value"},
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalCertificate_base64(t *testing.T) {
+	resourceName := "azuread_service_principal_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalCertificate_base64(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalCertificateExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2036-10-15T09:19:33Z"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalCertificate_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_service_principal_certificate.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalCertificate_basic(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalCertificateExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADServicePrincipalCertificate_requiresImport(applicationId),
+				ExpectError: testRequiresImportError("azuread_service_principal_certificate"),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalCertificate_customKeyIdAndDates(t *testing.T) {
+	resourceName := "azuread_service_principal_certificate.test"
+	applicationId := uuid.New().String()
+	keyId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalCertificateCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalCertificate_customKeyIdAndDates(applicationId, keyId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalCertificateExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "key_id", keyId),
+					resource.TestCheckResourceAttr(resourceName, "start_date", "2027-01-01T01:02:03Z"),
+					resource.TestCheckResourceAttr(resourceName, "end_date", "2028-01-01T01:02:03Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccADServicePrincipalCertificate_template(applicationId string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  name = "acctestspa%s"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = "${azuread_application.test.application_id}"
+}
+`, applicationId)
+}
+
+func testAccADServicePrincipalCertificate_basic(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_certificate" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  value                = <<EOT
+%s
+EOT
+}
+`, testAccADServicePrincipalCertificate_template(applicationId), testCertificatePEM)
+}
+
+func testAccADServicePrincipalCertificate_base64(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_certificate" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  encoding             = "base64"
+  value                = "%s"
+}
+`, testAccADServicePrincipalCertificate_template(applicationId), testCertificateBase64)
+}
+
+func testAccADServicePrincipalCertificate_requiresImport(applicationId string) string {
+	template := testAccADServicePrincipalCertificate_basic(applicationId)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_certificate" "import" {
+  service_principal_id = "${azuread_service_principal_certificate.test.service_principal_id}"
+  key_id               = "${azuread_service_principal_certificate.test.key_id}"
+  value                = "${azuread_service_principal_certificate.test.value}"
+}
+`, template)
+}
+
+func testAccADServicePrincipalCertificate_customKeyIdAndDates(applicationId, keyId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_certificate" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  key_id               = "%s"
+  start_date           = "2027-01-01T01:02:03Z"
+  end_date             = "2028-01-01T01:02:03Z"
+  value                = <<EOT
+%s
+EOT
+}
+`, testAccADServicePrincipalCertificate_template(applicationId), keyId, testCertificatePEM)
+}
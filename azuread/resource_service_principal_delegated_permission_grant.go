@@ -0,0 +1,254 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
+)
+
+// the API requires both of these but Azure Active Directory doesn't enforce them
+const (
+	delegatedPermissionGrantStartTime  = "0001-01-01T00:00:00"
+	delegatedPermissionGrantExpiryTime = "9000-01-01T00:00:00"
+)
+
+func resourceServicePrincipalDelegatedPermissionGrant() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServicePrincipalDelegatedPermissionGrantCreate,
+		Read:   resourceServicePrincipalDelegatedPermissionGrantRead,
+		Update: resourceServicePrincipalDelegatedPermissionGrantUpdate,
+		Delete: resourceServicePrincipalDelegatedPermissionGrantDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: resourceServicePrincipalDelegatedPermissionGrantCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"service_principal_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"resource_service_principal_object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+
+			"scopes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"consent_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  graph.OAuth2PermissionGrantConsentTypeAllPrincipals,
+				ValidateFunc: validation.StringInSlice([]string{
+					graph.OAuth2PermissionGrantConsentTypeAllPrincipals,
+					graph.OAuth2PermissionGrantConsentTypePrincipal,
+				}, false),
+			},
+
+			"principal_object_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+		},
+	}
+}
+
+// resourceServicePrincipalDelegatedPermissionGrantCustomizeDiff checks `principal_object_id` is given only for grants
+// with a `consent_type` of `Principal`, so that invalid configurations fail at plan time
+func resourceServicePrincipalDelegatedPermissionGrantCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	consentType := d.Get("consent_type").(string)
+
+	// an unknown principal will be given once it's been created
+	hasPrincipal := d.Get("principal_object_id").(string) != "" || !d.NewValueKnown("principal_object_id")
+
+	if consentType == graph.OAuth2PermissionGrantConsentTypePrincipal && !hasPrincipal {
+		return fmt.Errorf("`principal_object_id` must be specified when `consent_type` is %q", consentType)
+	}
+	if consentType == graph.OAuth2PermissionGrantConsentTypeAllPrincipals && hasPrincipal {
+		return fmt.Errorf("`principal_object_id` cannot be specified when `consent_type` is %q", consentType)
+	}
+
+	return nil
+}
+
+func resourceServicePrincipalDelegatedPermissionGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).oauth2Client
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	clientId := d.Get("service_principal_object_id").(string)
+	resourceId := d.Get("resource_service_principal_object_id").(string)
+	consentType := d.Get("consent_type").(string)
+	principalId := d.Get("principal_object_id").(string)
+
+	azureADLockByName(servicePrincipalResourceName, clientId)
+	defer azureADUnlockByName(servicePrincipalResourceName, clientId)
+
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existing, err := graph.OAuth2PermissionGrantFind(client, ctx, clientId, resourceId, consentType, principalId)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil && existing.ObjectID != nil {
+			return tf.ImportAsExistsError("azuread_service_principal_delegated_permission_grant", *existing.ObjectID)
+		}
+	}
+
+	properties := graph.OAuth2PermissionGrant{
+		ClientID:    p.String(clientId),
+		ResourceID:  p.String(resourceId),
+		ConsentType: p.String(consentType),
+		Scope:       p.String(expandDelegatedPermissionGrantScopes(d.Get("scopes").(*schema.Set))),
+		StartTime:   p.String(delegatedPermissionGrantStartTime),
+		ExpiryTime:  p.String(delegatedPermissionGrantExpiryTime),
+	}
+	if principalId != "" {
+		properties.PrincipalID = p.String(principalId)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	// the service principals, or the principal, may have only just been created
+	var grant graph.OAuth2PermissionGrant
+	if err := graph.RetryOnReplicationDelay(timeout, func() (autorest.Response, error) {
+		var err error
+		grant, err = client.Create(ctx, properties)
+		return grant.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error creating Delegated Permission Grant for Service Principal %q on Resource %q: %+v", clientId, resourceId, err)
+	}
+	if grant.ObjectID == nil {
+		return fmt.Errorf("Delegated Permission Grant objectId is nil")
+	}
+
+	d.SetId(*grant.ObjectID)
+
+	if err := graph.WaitForCreation(timeout, func() (autorest.Response, error) {
+		resp, err := client.Get(ctx, *grant.ObjectID)
+		return resp.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for Delegated Permission Grant %q to become available: %+v", *grant.ObjectID, err)
+	}
+
+	return resourceServicePrincipalDelegatedPermissionGrantRead(d, meta)
+}
+
+func resourceServicePrincipalDelegatedPermissionGrantRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).oauth2Client
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	grant, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if ar.ResponseWasNotFound(grant.Response) {
+			log.Printf("[DEBUG] Delegated Permission Grant %q was not found - removing from state!", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Delegated Permission Grant %q: %+v", d.Id(), err)
+	}
+
+	d.Set("service_principal_object_id", grant.ClientID)
+	d.Set("resource_service_principal_object_id", grant.ResourceID)
+	d.Set("consent_type", grant.ConsentType)
+	d.Set("principal_object_id", grant.PrincipalID)
+
+	scopes := make([]string, 0)
+	if grant.Scope != nil {
+		scopes = strings.Fields(*grant.Scope)
+	}
+	if err := d.Set("scopes", scopes); err != nil {
+		return fmt.Errorf("Error setting `scopes`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceServicePrincipalDelegatedPermissionGrantUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).oauth2Client
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if d.HasChange("scopes") {
+		properties := graph.OAuth2PermissionGrant{
+			Scope: p.String(expandDelegatedPermissionGrantScopes(d.Get("scopes").(*schema.Set))),
+		}
+
+		if _, err := client.Update(ctx, d.Id(), properties); err != nil {
+			return fmt.Errorf("Error updating scopes of Delegated Permission Grant %q: %+v", d.Id(), err)
+		}
+
+		if err := graph.WaitForReplication(d.Timeout(schema.TimeoutUpdate), func() (autorest.Response, bool, error) {
+			grant, err := client.Get(ctx, d.Id())
+			return grant.Response, err == nil && graph.OAuth2PermissionGrantScopesEqual(properties.Scope, grant.Scope), err
+		}); err != nil {
+			return fmt.Errorf("Error waiting for the scopes of Delegated Permission Grant %q to replicate: %+v", d.Id(), err)
+		}
+	}
+
+	return resourceServicePrincipalDelegatedPermissionGrantRead(d, meta)
+}
+
+func resourceServicePrincipalDelegatedPermissionGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).oauth2Client
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	clientId := d.Get("service_principal_object_id").(string)
+	azureADLockByName(servicePrincipalResourceName, clientId)
+	defer azureADUnlockByName(servicePrincipalResourceName, clientId)
+
+	if resp, err := client.Delete(ctx, d.Id()); err != nil {
+		if !ar.ResponseWasNotFound(resp) {
+			return fmt.Errorf("Error deleting Delegated Permission Grant %q: %+v", d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// the API represents scopes as a single space separated string
+func expandDelegatedPermissionGrantScopes(input *schema.Set) string {
+	scopes := tf.ExpandStringSlice(input.List())
+	sort.Strings(scopes)
+	return strings.Join(scopes, " ")
+}
@@ -0,0 +1,286 @@
+package azuread
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+)
+
+func TestAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(t *testing.T) {
+	resourceName := "azuread_service_principal_delegated_permission_grant.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, `"user_impersonation"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "consent_type", "AllPrincipals"),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalDelegatedPermissionGrant_principal(t *testing.T) {
+	resourceName := "azuread_service_principal_delegated_permission_grant.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalDelegatedPermissionGrant_principal(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "consent_type", "Principal"),
+					resource.TestCheckResourceAttrSet(resourceName, "principal_object_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalDelegatedPermissionGrant_update(t *testing.T) {
+	resourceName := "azuread_service_principal_delegated_permission_grant.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, `"user_impersonation"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, `"user_impersonation", "tasks_read"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalDelegatedPermissionGrant_principalObjectIdConsentType(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAzureADServicePrincipalDelegatedPermissionGrant_consentType("Principal", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`principal_object_id` must be specified when `consent_type` is \"Principal\""),
+			},
+			{
+				Config:      testAccAzureADServicePrincipalDelegatedPermissionGrant_consentType("AllPrincipals", "22222222-2222-2222-2222-222222222222"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`principal_object_id` cannot be specified when `consent_type` is \"AllPrincipals\""),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalDelegatedPermissionGrant_requiresImport(t *testing.T) {
+	if !testAccRequireResourcesToBeImported() {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azuread_service_principal_delegated_permission_grant.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, `"user_impersonation"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureADServicePrincipalDelegatedPermissionGrant_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_service_principal_delegated_permission_grant"),
+			},
+		},
+	})
+}
+
+func testCheckAzureADServicePrincipalDelegatedPermissionGrantExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).oauth2Client
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, rs.Primary.ID)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Delegated Permission Grant %q does not exist", rs.Primary.ID)
+			}
+			return fmt.Errorf("Bad: Get on Azure AD oauth2Client: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureADServicePrincipalDelegatedPermissionGrantDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azuread_service_principal_delegated_permission_grant" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).oauth2Client
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, rs.Primary.ID)
+		if err != nil {
+			if ar.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("Delegated Permission Grant %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAzureADServicePrincipalDelegatedPermissionGrant_template(id string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "resource" {
+  name = "acctest%[1]s-resource"
+
+  oauth2_permissions {
+    admin_consent_description  = "Allow the application to access acctest%[1]s on behalf of the signed-in user."
+    admin_consent_display_name = "Access acctest%[1]s"
+    value                      = "user_impersonation"
+  }
+
+  oauth2_permissions {
+    admin_consent_description  = "Allow the application to read tasks on behalf of the signed-in user."
+    admin_consent_display_name = "Read tasks"
+    value                      = "tasks_read"
+  }
+}
+
+resource "azuread_service_principal" "resource" {
+  application_id = "${azuread_application.resource.application_id}"
+}
+
+resource "azuread_application" "test" {
+  name = "acctest%[1]s"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = "${azuread_application.test.application_id}"
+}
+`, id)
+}
+
+func testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, scopes string) string {
+	template := testAccAzureADServicePrincipalDelegatedPermissionGrant_template(id)
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_delegated_permission_grant" "test" {
+  service_principal_object_id          = "${azuread_service_principal.test.id}"
+  resource_service_principal_object_id = "${azuread_service_principal.resource.id}"
+  scopes                               = [%[2]s]
+}
+`, template, scopes)
+}
+
+func testAccAzureADServicePrincipalDelegatedPermissionGrant_principal(id, password string) string {
+	template := testAccAzureADServicePrincipalDelegatedPermissionGrant_template(id)
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_domains" "tenant_domain" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctest%[2]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+  display_name        = "acctest%[2]s"
+  password            = "%[3]s"
+}
+
+resource "azuread_service_principal_delegated_permission_grant" "test" {
+  service_principal_object_id          = "${azuread_service_principal.test.id}"
+  resource_service_principal_object_id = "${azuread_service_principal.resource.id}"
+  scopes                               = ["user_impersonation"]
+  consent_type                         = "Principal"
+  principal_object_id                  = "${azuread_user.test.id}"
+}
+`, template, id, password)
+}
+
+func testAccAzureADServicePrincipalDelegatedPermissionGrant_consentType(consentType, principalId string) string {
+	principal := ""
+	if principalId != "" {
+		principal = fmt.Sprintf(`principal_object_id                  = "%s"`, principalId)
+	}
+
+	return fmt.Sprintf(`
+resource "azuread_service_principal_delegated_permission_grant" "test" {
+  service_principal_object_id          = "00000000-0000-0000-0000-000000000000"
+  resource_service_principal_object_id = "11111111-1111-1111-1111-111111111111"
+  scopes                               = ["user_impersonation"]
+  consent_type                         = "%s"
+  %s
+}
+`, consentType, principal)
+}
+
+func testAccAzureADServicePrincipalDelegatedPermissionGrant_requiresImport(id string) string {
+	template := testAccAzureADServicePrincipalDelegatedPermissionGrant_allPrincipals(id, `"user_impersonation"`)
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_delegated_permission_grant" "import" {
+  service_principal_object_id          = "${azuread_service_principal_delegated_permission_grant.test.service_principal_object_id}"
+  resource_service_principal_object_id = "${azuread_service_principal_delegated_permission_grant.test.resource_service_principal_object_id}"
+  scopes                               = ["user_impersonation"]
+}
+`, template)
+}
@@ -2,7 +2,9 @@ package azuread
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
@@ -103,7 +105,7 @@ func TestAccAzureADServicePrincipalPassword_basic(t *testing.T) {
 }
 
 func TestAccAzureADServicePrincipalPassword_requiresImport(t *testing.T) {
-	if !requireResourcesToBeImported {
+	if !testAccRequireResourcesToBeImported() {
 		t.Skip("Skipping since resources aren't required to be imported")
 		return
 	}
@@ -180,6 +182,94 @@ func TestAccAzureADServicePrincipalPassword_relativeEndDate(t *testing.T) {
 	})
 }
 
+func TestAccAzureADServicePrincipalPassword_rotation(t *testing.T) {
+	resourceName := "azuread_service_principal_password.test"
+	applicationId := uuid.New().String()
+	value := uuid.New().String()
+	var id string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalPassword_rotation(applicationId, value, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rotation_days", "5"),
+					resource.TestCheckResourceAttr(resourceName, "expires_in_days", "9"),
+					testCheckResourceIdChange(resourceName, &id, false),
+				),
+			},
+			{
+				// once the password expires within the rotation window it's replaced, and only once since the
+				// replacement is valid for the full `end_date_relative` - the plan following the apply must be empty
+				PreConfig: func() {
+					testAccAgePasswordCredential(t, testAccProvider.Meta().(*ArmClient).servicePrincipalsClient, id, 48*time.Hour)
+				},
+				Config: testAccADServicePrincipalPassword_rotation(applicationId, value, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "expires_in_days", "9"),
+					testCheckResourceIdChange(resourceName, &id, true),
+				),
+			},
+			{
+				Config:      testAccADServicePrincipalPassword_rotation(applicationId, value, 10),
+				ExpectError: regexp.MustCompile("`rotation_days` \\(10\\) must be less than the lifetime"),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalPassword_generatedValue(t *testing.T) {
+	resourceName := "azuread_service_principal_password.test"
+	applicationId := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalPassword_generatedValue(applicationId),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalPasswordExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "value", regexp.MustCompile("^[a-zA-Z0-9]{40}$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADServicePrincipalPassword_description(t *testing.T) {
+	resourceName := "azuread_service_principal_password.test"
+	applicationId := uuid.New().String()
+	value := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADServicePrincipalPasswordCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADServicePrincipalPassword_description(applicationId, value),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalPasswordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", "terraform-pipeline"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"end_date_relative", "value"},
+			},
+		},
+	})
+}
+
 func testAccADServicePrincipalPassword_template(applicationId string) string {
 	return fmt.Sprintf(`
 resource "azuread_application" "test" {
@@ -242,3 +332,42 @@ resource "azuread_service_principal_password" "test" {
 }
 `, testAccADServicePrincipalPassword_template(applicationId), value)
 }
+
+func testAccADServicePrincipalPassword_rotation(applicationId, value string, rotationDays int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_password" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  value                = "%s"
+  end_date_relative    = "240h"
+  rotation_days        = %d
+}
+`, testAccADServicePrincipalPassword_template(applicationId), value, rotationDays)
+}
+
+func testAccADServicePrincipalPassword_generatedValue(applicationId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_password" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  value_length         = 40
+  value_characters     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+  end_date_relative    = "8760h"
+}
+`, testAccADServicePrincipalPassword_template(applicationId))
+}
+
+func testAccADServicePrincipalPassword_description(applicationId, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_service_principal_password" "test" {
+  service_principal_id = "${azuread_service_principal.test.id}"
+  description          = "terraform-pipeline"
+  value                = "%s"
+  end_date_relative    = "8760h"
+}
+`, testAccADServicePrincipalPassword_template(applicationId), value)
+}
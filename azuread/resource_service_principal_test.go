@@ -37,6 +37,29 @@ func TestAccAzureADServicePrincipal_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureADServicePrincipal_requiresImport(t *testing.T) {
+	resourceName := "azuread_service_principal.test"
+	id := uuid.New().String()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccStrictImportProviders(),
+		CheckDestroy: testCheckADServicePrincipalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderStrictImport() + testAccADServicePrincipal_basic(id),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADServicePrincipalExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADServicePrincipal_requiresImport(id),
+				ExpectError: testRequiresImportError("azuread_service_principal"),
+			},
+		},
+	})
+}
+
 func TestAccAzureADServicePrincipal_complete(t *testing.T) {
 	resourceName := "azuread_service_principal.test"
 	id := uuid.New().String()
@@ -120,6 +143,18 @@ resource "azuread_service_principal" "test" {
 `, id)
 }
 
+func testAccADServicePrincipal_requiresImport(id string) string {
+	template := testAccADServicePrincipal_basic(id)
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "azuread_service_principal" "import" {
+  application_id = "${azuread_service_principal.test.application_id}"
+}
+`, testAccProviderStrictImport(), template)
+}
+
 func testAccADServicePrincipal_complete(id string) string {
 	return fmt.Sprintf(`
 resource "azuread_application" "test" {
@@ -1,15 +1,21 @@
 package azuread
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/ar"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/graph"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/p"
+	passwordgen "github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/password"
+	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azuread/azuread/helpers/validate"
 )
 
@@ -23,6 +29,15 @@ func resourceUser() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: resourceUserCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"user_principal_name": {
 				Type:         schema.TypeString,
@@ -51,11 +66,28 @@ func resourceUser() *schema.Resource {
 
 			"password": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				Sensitive:    true,
 				ValidateFunc: validation.StringLenBetween(1, 256), //currently the max length for AAD passwords is 256
 			},
 
+			"password_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"password"},
+				ValidateFunc:  validation.IntBetween(8, 256),
+			},
+
+			"password_characters": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"password"},
+				ValidateFunc:  validate.NoEmptyStrings,
+			},
+
 			"force_password_change": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -66,13 +98,132 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"given_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"surname": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"job_title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"department": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"company_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"office_location": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"usage_location": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 2),
+			},
+
+			"mobile_phone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"street_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"city": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"country": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"postal_code": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"immutable_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"user_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(graphrbac.Member),
+					string(graphrbac.Guest),
+				}, false),
+			},
+
+			"other_mails": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.StringIsEmailAddress,
+				},
+			},
 		},
 	}
 }
 
 func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).usersClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	userPrincipalName := d.Get("user_principal_name").(string)
 	displayName := d.Get("display_name").(string)
@@ -81,11 +232,30 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	password := d.Get("password").(string)
 	forcePasswordChange := d.Get("force_password_change").(bool)
 
+	if password == "" {
+		generated, err := passwordgen.Generate(d.Get("password_length").(int), d.Get("password_characters").(string))
+		if err != nil {
+			return fmt.Errorf("Error generating a password for User %q: %+v", userPrincipalName, err)
+		}
+		password = generated
+	}
+
 	//default mail nickname to the first part of the UPN (matches the portal)
 	if mailNickName == "" {
 		mailNickName = strings.Split(userPrincipalName, "@")[0]
 	}
 
+	if meta.(*ArmClient).requireResourcesToBeImported {
+		existing, err := graph.UserFindByPrincipalName(client, ctx, userPrincipalName)
+		if err != nil {
+			return fmt.Errorf("Error checking for existing User %q: %+v", userPrincipalName, err)
+		}
+
+		if existing != nil && existing.ObjectID != nil {
+			return tf.ImportAsExistsError("azuread_user", *existing.ObjectID)
+		}
+	}
+
 	userCreateParameters := graphrbac.UserCreateParameters{
 		AccountEnabled: &accountEnabled,
 		DisplayName:    &displayName,
@@ -94,32 +264,47 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 			ForceChangePasswordNextLogin: &forcePasswordChange,
 			Password:                     &password,
 		},
-		UserPrincipalName: &userPrincipalName,
+		UserPrincipalName:    &userPrincipalName,
+		AdditionalProperties: make(map[string]interface{}),
 	}
 
-	user, err := client.Create(ctx, userCreateParameters)
-	if err != nil {
-		return fmt.Errorf("Error creating User %q: %+v", userPrincipalName, err)
+	for key, property := range graph.UserProperties {
+		if v, ok := d.GetOk(key); ok {
+			userCreateParameters.AdditionalProperties[property] = v.(string)
+		}
 	}
 
-	objectId := user.ObjectID
+	if v, ok := d.GetOk("other_mails"); ok {
+		userCreateParameters.AdditionalProperties["otherMails"] = tf.ExpandStringSlicePtr(v.([]interface{}))
+	}
 
-	resp, err := client.Get(ctx, *objectId)
+	user, err := client.Create(ctx, userCreateParameters)
 	if err != nil {
-		return fmt.Errorf("Error retrieving User (%q) with ObjectID %q: %+v", userPrincipalName, *objectId, err)
+		return fmt.Errorf("Error creating User %q: %+v", userPrincipalName, err)
 	}
-	if resp.ObjectID == nil {
+
+	if user.ObjectID == nil {
 		return fmt.Errorf("User objectId is nil")
 	}
+	d.SetId(*user.ObjectID)
+
+	// the password is never returned by the API, so keep the one which was sent as it may have been generated
+	d.Set("password", password)
 
-	d.SetId(*resp.ObjectID)
+	if err := graph.WaitForCreation(d.Timeout(schema.TimeoutCreate), func() (autorest.Response, error) {
+		resp, err := client.Get(ctx, *user.ObjectID)
+		return resp.Response, err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for User %q to become available: %+v", userPrincipalName, err)
+	}
 
 	return resourceUserRead(d, meta)
 }
 
 func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).usersClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	objectId := d.Id()
 
@@ -139,14 +324,54 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("mail_nickname", user.MailNickname)
 	d.Set("account_enabled", user.AccountEnabled)
 
+	values, otherMails, err := graph.UserPropertyValues(user)
+	if err != nil {
+		return fmt.Errorf("Error flattening User with ID %q: %+v", objectId, err)
+	}
+
+	for key, value := range values {
+		d.Set(key, value)
+	}
+
+	if err := d.Set("other_mails", otherMails); err != nil {
+		return fmt.Errorf("Error setting `other_mails`: %+v", err)
+	}
+
 	return nil
 }
 
+// resourceUserCustomizeDiff generates a new password when the options the current one was generated with change,
+// which are otherwise only used when the User is created
+func resourceUserCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.HasChange("password") {
+		return nil
+	}
+
+	if !d.HasChange("password_length") && !d.HasChange("password_characters") {
+		return nil
+	}
+
+	// setting the password clears the diff of every key it prefixes, so the new options are set again afterwards
+	length := d.Get("password_length").(int)
+	characters := d.Get("password_characters").(string)
+
+	if err := d.SetNewComputed("password"); err != nil {
+		return err
+	}
+	if err := d.SetNew("password_length", length); err != nil {
+		return err
+	}
+	return d.SetNew("password_characters", characters)
+}
+
 func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).usersClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
-	var userUpdateParameters graphrbac.UserUpdateParameters
+	userUpdateParameters := graphrbac.UserUpdateParameters{
+		AdditionalProperties: make(map[string]interface{}),
+	}
 
 	if d.HasChange("display_name") {
 		displayName := d.Get("display_name").(string)
@@ -163,10 +388,20 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		userUpdateParameters.AccountEnabled = p.Bool(accountEnabled)
 	}
 
-	if d.HasChange("password") {
+	if d.HasChange("password") || d.HasChange("password_length") || d.HasChange("password_characters") {
 		password := d.Get("password").(string)
+		if !d.HasChange("password") || password == "" {
+			generated, err := passwordgen.Generate(d.Get("password_length").(int), d.Get("password_characters").(string))
+			if err != nil {
+				return fmt.Errorf("Error generating a password for User with ID %q: %+v", d.Id(), err)
+			}
+			password = generated
+		}
 		forcePasswordChange := d.Get("force_password_change").(bool)
 
+		// the password is never returned by the API, so keep the one which was sent as it may have been generated
+		d.Set("password", password)
+
 		passwordProfile := &graphrbac.PasswordProfile{
 			ForceChangePasswordNextLogin: &forcePasswordChange,
 			Password:                     &password,
@@ -175,16 +410,36 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		userUpdateParameters.PasswordProfile = passwordProfile
 	}
 
+	// only the properties which have changed are sent, those removed from the configuration are computed and left as
+	// they are, so that profile data managed elsewhere (e.g. synchronised from an HR system) isn't cleared
+	for key, property := range graph.UserProperties {
+		if d.HasChange(key) {
+			userUpdateParameters.AdditionalProperties[property] = d.Get(key).(string)
+		}
+	}
+
+	if d.HasChange("other_mails") {
+		userUpdateParameters.AdditionalProperties["otherMails"] = tf.ExpandStringSlicePtr(d.Get("other_mails").([]interface{}))
+	}
+
 	if _, err := client.Update(ctx, d.Id(), userUpdateParameters); err != nil {
 		return fmt.Errorf("Error updating User with ID %q: %+v", d.Id(), err)
 	}
 
+	if err := graph.WaitForReplication(d.Timeout(schema.TimeoutUpdate), func() (autorest.Response, bool, error) {
+		user, err := client.Get(ctx, d.Id())
+		return user.Response, err == nil && graph.UserChangesReplicated(userUpdateParameters, user), err
+	}); err != nil {
+		return fmt.Errorf("Error waiting for changes to User with ID %q to replicate: %+v", d.Id(), err)
+	}
+
 	return resourceUserRead(d, meta)
 }
 
 func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).usersClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	resp, err := client.Delete(ctx, d.Id())
 	if err != nil {
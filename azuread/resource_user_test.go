@@ -2,7 +2,9 @@ package azuread
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 
@@ -44,6 +46,30 @@ func TestAccAzureADUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureADUser_requiresImport(t *testing.T) {
+	resourceName := "azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccStrictImportProviders(),
+		CheckDestroy: testCheckADUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderStrictImport() + testAccADUser_basic(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccADUser_requiresImport(id, password),
+				ExpectError: testRequiresImportError("azuread_user"),
+			},
+		},
+	})
+}
+
 func TestAccAzureADUser_complete(t *testing.T) {
 	resourceName := "azuread_user.test"
 	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
@@ -77,6 +103,177 @@ func TestAccAzureADUser_complete(t *testing.T) {
 	})
 }
 
+func TestAccAzureADUser_generatedPassword(t *testing.T) {
+	resourceName := "azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADUser_generatedPassword(id, 24),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "password", regexp.MustCompile("^.{24}$")),
+				),
+			},
+			{
+				Config: testAccADUser_generatedPassword(id, 32),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "password", regexp.MustCompile("^.{32}$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADUser_profile(t *testing.T) {
+	resourceName := "azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADUser_profile(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "given_name", "Ada"),
+					resource.TestCheckResourceAttr(resourceName, "surname", "Lovelace"),
+					resource.TestCheckResourceAttr(resourceName, "job_title", "Contractor"),
+					resource.TestCheckResourceAttr(resourceName, "department", "Engineering"),
+					resource.TestCheckResourceAttr(resourceName, "company_name", "Analytical Engines Ltd"),
+					resource.TestCheckResourceAttr(resourceName, "office_location", "Building 1"),
+					resource.TestCheckResourceAttr(resourceName, "usage_location", "GB"),
+					resource.TestCheckResourceAttr(resourceName, "mobile_phone", "+44 20 7946 0000"),
+					resource.TestCheckResourceAttr(resourceName, "street_address", "1 Example Street"),
+					resource.TestCheckResourceAttr(resourceName, "city", "London"),
+					resource.TestCheckResourceAttr(resourceName, "state", "Greater London"),
+					resource.TestCheckResourceAttr(resourceName, "country", "United Kingdom"),
+					resource.TestCheckResourceAttr(resourceName, "postal_code", "SW1A 1AA"),
+					resource.TestCheckResourceAttr(resourceName, "immutable_id", id),
+					resource.TestCheckResourceAttr(resourceName, "user_type", "Guest"),
+					resource.TestCheckResourceAttr(resourceName, "other_mails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "other_mails.0", fmt.Sprintf("acctest%s@example.com", id)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_password_change",
+					"password", // not returned from API, sensitive
+				},
+			},
+			{
+				Config: testAccADUser_profileUpdate(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "given_name", "Ada"),
+					resource.TestCheckResourceAttr(resourceName, "surname", "King"),
+					resource.TestCheckResourceAttr(resourceName, "job_title", "Engineer"),
+					resource.TestCheckResourceAttr(resourceName, "department", "Engineering"),
+					resource.TestCheckResourceAttr(resourceName, "city", "London"),
+					resource.TestCheckResourceAttr(resourceName, "user_type", "Member"),
+					resource.TestCheckResourceAttr(resourceName, "other_mails.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureADUser_profileImported(t *testing.T) {
+	resourceName := "azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADUser_profile(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_password_change",
+					"password", // not returned from API, sensitive
+				},
+			},
+			{
+				// a configuration without the profile, such as one written for an imported User, mustn't clear it
+				Config:   testAccADUser_basic(id, password),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccADUser_basic(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "surname", "Lovelace"),
+					resource.TestCheckResourceAttr(resourceName, "city", "London"),
+					resource.TestCheckResourceAttr(resourceName, "other_mails.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAzureADUser_profileReplicationDelay checks an update waits for every changed profile property to replicate,
+// it's run serially as the replication delay applies to the fake Graph API shared by every test
+func TestAccAzureADUser_profileReplicationDelay(t *testing.T) {
+	if !testAccOffline() {
+		t.Skip("Skipping since replication delays can only be simulated offline")
+		return
+	}
+
+	testAccFakeGraph.SetReplicationDelay(3 * time.Second)
+	defer testAccFakeGraph.SetReplicationDelay(testAccFakeGraphReplicationDelay)
+
+	resourceName := "azuread_user.test"
+	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
+	password := id + "p@$$wR2"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckADUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccADUser_profile(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "surname", "Lovelace"),
+					resource.TestCheckResourceAttr(resourceName, "job_title", "Contractor"),
+				),
+			},
+			{
+				Config: testAccADUser_profileUpdate(id, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckADUserExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "surname", "King"),
+					resource.TestCheckResourceAttr(resourceName, "job_title", "Engineer"),
+					resource.TestCheckResourceAttr(resourceName, "department", "Engineering"),
+					resource.TestCheckResourceAttr(resourceName, "other_mails.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureADUser_update(t *testing.T) {
 	resourceName := "azuread_user.test"
 	id := acctest.RandStringFromCharSet(7, acctest.CharSetAlphaNum)
@@ -186,6 +383,84 @@ resource "azuread_user" "test" {
 `, id, password)
 }
 
+func testAccADUser_requiresImport(id string, password string) string {
+	template := testAccADUser_basic(id, password)
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "azuread_user" "import" {
+	user_principal_name   = "${azuread_user.test.user_principal_name}"
+	display_name          = "${azuread_user.test.display_name}"
+	password              = "%s"
+}
+`, testAccProviderStrictImport(), template, password)
+}
+
+func testAccADUser_generatedPassword(id string, length int) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+	only_initial = true
+}
+
+resource "azuread_user" "test" {
+	user_principal_name   = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+	display_name          = "acctest%[1]s"
+	password_length       = %[2]d
+}
+`, id, length)
+}
+
+func testAccADUser_profile(id string, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+	only_initial = true
+}
+
+resource "azuread_user" "test" {
+	user_principal_name   = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+	display_name          = "acctest%[1]s"
+	password              = "%[2]s"
+	given_name            = "Ada"
+	surname               = "Lovelace"
+	job_title             = "Contractor"
+	department            = "Engineering"
+	company_name          = "Analytical Engines Ltd"
+	office_location       = "Building 1"
+	usage_location        = "GB"
+	mobile_phone          = "+44 20 7946 0000"
+	street_address        = "1 Example Street"
+	city                  = "London"
+	state                 = "Greater London"
+	country               = "United Kingdom"
+	postal_code           = "SW1A 1AA"
+	immutable_id          = "%[1]s"
+	user_type             = "Guest"
+	other_mails           = ["acctest%[1]s@example.com", "acctest%[1]s@example.net"]
+}
+`, id, password)
+}
+
+func testAccADUser_profileUpdate(id string, password string) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "tenant_domain" {
+	only_initial = true
+}
+
+resource "azuread_user" "test" {
+	user_principal_name   = "acctest%[1]s@${data.azuread_domains.tenant_domain.domains.0.domain_name}"
+	display_name          = "acctest%[1]s"
+	password              = "%[2]s"
+	given_name            = "Ada"
+	surname               = "King"
+	job_title             = "Engineer"
+	usage_location        = "GB"
+	user_type             = "Member"
+	other_mails           = []
+}
+`, id, password)
+}
+
 func testAccADUser_complete(id string, password string) string {
 	return fmt.Sprintf(`
 
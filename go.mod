@@ -7,6 +7,8 @@ require (
 	github.com/gofrs/uuid v3.2.0+incompatible
 	github.com/google/uuid v0.0.0-20170814143639-7e072fc3a7be
 	github.com/hashicorp/go-azure-helpers v0.0.0-20190129193224-166dfd221bb2
+	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/go-uuid v1.0.1
 	github.com/hashicorp/terraform v0.12.0
+	golang.org/x/crypto v0.0.0-20190426145343-a29dc8fdc734
 )